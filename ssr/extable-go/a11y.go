@@ -0,0 +1,91 @@
+package extable
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type A11yIssueKind string
+
+const (
+	A11yMissingScope         A11yIssueKind = "missingScope"
+	A11yMissingCaption       A11yIssueKind = "missingCaption"
+	A11yLowInformationHeader A11yIssueKind = "lowInformationHeader"
+	A11yColorOnly            A11yIssueKind = "colorOnly"
+)
+
+type A11yIssue struct {
+	Kind A11yIssueKind
+	// ColKey is the column concerned, empty for table-level issues.
+	ColKey  string
+	Message string
+}
+
+var (
+	a11yTablePattern   = regexp.MustCompile(`<table(?:\s[^>]*)?>`)
+	a11yCaptionPattern = regexp.MustCompile(`<caption[\s>]`)
+	a11yHeaderPattern  = regexp.MustCompile(`(?s)<th(\s[^>]*)?>(.*?)</th>`)
+	a11yCellPattern    = regexp.MustCompile(`<td(\s[^>]*)?>`)
+	a11yTagPattern     = regexp.MustCompile(`<[^>]*>`)
+	a11yAttrPattern    = regexp.MustCompile(`\s([a-zA-Z-]+)="([^"]*)"`)
+)
+
+// AuditAccessibility inspects rendered output for common table accessibility
+// problems. It is a heuristic over the renderer's own markup, meant for
+// release gates, not a general HTML validator.
+func AuditAccessibility(result Result) []A11yIssue {
+	markup := result.HTML
+	issues := make([]A11yIssue, 0)
+	for _, table := range a11yTablePattern.FindAllString(markup, -1) {
+		attrs := a11yAttrs(table)
+		if attrs["aria-label"] == "" && attrs["aria-labelledby"] == "" && !a11yCaptionPattern.MatchString(markup) {
+			issues = append(issues, A11yIssue{Kind: A11yMissingCaption, Message: "table has no caption or aria-label"})
+			break
+		}
+	}
+
+	described := make(map[string]bool)
+	for _, match := range a11yHeaderPattern.FindAllStringSubmatch(markup, -1) {
+		attrs := a11yAttrs(match[1])
+		if strings.Contains(attrs["class"], "extable-corner") {
+			continue
+		}
+		key := attrs["data-col-key"]
+		if attrs["scope"] == "" {
+			issues = append(issues, A11yIssue{Kind: A11yMissingScope, ColKey: key, Message: "header cell has no scope"})
+		}
+		// Group row fillers are empty by design; the column header below names the column.
+		if attrs["scope"] != "col" || strings.Contains(attrs["class"], "extable-header-group") {
+			continue
+		}
+		describedBy := attrs["aria-describedby"] != "" || strings.Contains(match[2], "<abbr")
+		described[key] = describedBy
+		text := strings.TrimSpace(html.UnescapeString(a11yTagPattern.ReplaceAllString(match[2], "")))
+		if !describedBy && utf8.RuneCountInString(text) < 3 {
+			issues = append(issues, A11yIssue{Kind: A11yLowInformationHeader, ColKey: key, Message: "header text " + strconv.Quote(text) + " is too short to identify the column; set Column.Description"})
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, match := range a11yCellPattern.FindAllStringSubmatch(markup, -1) {
+		attrs := a11yAttrs(match[1])
+		key := attrs["data-col-key"]
+		if reported[key] || described[key] || !strings.Contains(attrs["style"], "background-color") {
+			continue
+		}
+		reported[key] = true
+		issues = append(issues, A11yIssue{Kind: A11yColorOnly, ColKey: key, Message: "conditional background color is not explained; describe the scale in Column.Description"})
+	}
+	return issues
+}
+
+func a11yAttrs(tag string) map[string]string {
+	attrs := make(map[string]string)
+	for _, match := range a11yAttrPattern.FindAllStringSubmatch(tag, -1) {
+		attrs[match[1]] = html.UnescapeString(match[2])
+	}
+	return attrs
+}
@@ -0,0 +1,48 @@
+package extable
+
+import "testing"
+
+func TestAuditAccessibility(t *testing.T) {
+	min, max := 0.0, 10.0
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString, Header: "Name"},
+		{Key: "age", Type: ColumnTypeInt, Header: "Ag", Heatmap: &HeatmapSpec{Min: &min, Max: &max}},
+	}}
+	rows := []sampleRow{{Name: "a", Age: 1}, {Name: "b", Age: 9}}
+	result, err := RenderTableHTML(rows, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	kinds := make(map[A11yIssueKind]string)
+	for _, issue := range AuditAccessibility(result) {
+		if _, dup := kinds[issue.Kind]; dup {
+			t.Fatalf("duplicate issue %+v", issue)
+		}
+		kinds[issue.Kind] = issue.ColKey
+	}
+	if _, ok := kinds[A11yMissingCaption]; !ok {
+		t.Fatalf("expected missing caption, got %v", kinds)
+	}
+	if kinds[A11yLowInformationHeader] != "age" || kinds[A11yColorOnly] != "age" {
+		t.Fatalf("expected header and color issues on age, got %v", kinds)
+	}
+	if _, ok := kinds[A11yMissingScope]; ok {
+		t.Fatalf("renderer output should have scopes, got %v", kinds)
+	}
+
+	schema.Columns[1].Description = "Age in years, shaded from 0 to 10"
+	result, err = RenderTableHTML(rows, schema, Options{Caption: "People"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if issues := AuditAccessibility(result); len(issues) != 0 {
+		t.Fatalf("expected clean audit, got %+v", issues)
+	}
+}
+
+func TestAuditAccessibilityMissingScope(t *testing.T) {
+	issues := AuditAccessibility(Result{HTML: `<table aria-label="x"><thead><tr><th data-col-key="id">Identifier</th></tr></thead></table>`})
+	if len(issues) != 1 || issues[0].Kind != A11yMissingScope || issues[0].ColKey != "id" {
+		t.Fatalf("expected missing scope issue, got %+v", issues)
+	}
+}
@@ -0,0 +1,68 @@
+package extable
+
+import "strings"
+
+func visibleActions[T any](row T, col Column[T]) []Action[T] {
+	visible := make([]Action[T], 0, len(col.Actions))
+	for _, action := range col.Actions {
+		if action.VisibleIf == nil || action.VisibleIf(row) {
+			visible = append(visible, action)
+		}
+	}
+	return visible
+}
+
+// actionsKey lists the row's visible action IDs for the row cache key, since
+// VisibleIf may depend on more than the row's column values.
+func (r *tableRenderer[T]) actionsKey(row T) string {
+	var sb strings.Builder
+	for _, col := range r.columns {
+		if col.Type != ColumnTypeActions {
+			continue
+		}
+		sb.WriteString(col.Key)
+		for _, action := range visibleActions(row, col) {
+			sb.WriteString("\x1f")
+			sb.WriteString(action.ID)
+		}
+		sb.WriteString("\x1e")
+	}
+	return sb.String()
+}
+
+// renderActions writes a menu button and a hidden list of the row's visible
+// actions. The client runtime toggles the list and dispatches on data-action;
+// without it the menu stays closed, so the column is only useful with JS.
+func (r *tableRenderer[T]) renderActions(builder *htmlBuilder, row T, col Column[T]) {
+	visible := visibleActions(row, col)
+	if len(visible) == 0 {
+		return
+	}
+	var rowID string
+	if r.rowID != nil {
+		rowID = r.rowID(row)
+	}
+	builder.openTag("div", "class", "extable-actions")
+	builder.openTag("button", "class", "extable-actions-toggle", "type", "button", "aria-haspopup", "menu", "aria-expanded", "false", "aria-label", r.opts.messages().Actions)
+	builder.text("⋮")
+	builder.closeTag("button")
+	builder.openTag("ul", "class", "extable-actions-menu", "role", "menu", "hidden", "")
+	for _, action := range visible {
+		builder.openTag("li", "role", "none")
+		attrs := []string{"class", "extable-action", "type", "button", "role", "menuitem", "data-action", action.ID}
+		if rowID != "" {
+			attrs = append(attrs, "data-row-id", rowID)
+		}
+		builder.openTag("button", attrs...)
+		if action.Icon != "" {
+			builder.openTag("span", "class", "extable-action-icon", "aria-hidden", "true")
+			builder.raw(string(action.Icon))
+			builder.closeTag("span")
+		}
+		builder.text(action.Label)
+		builder.closeTag("button")
+		builder.closeTag("li")
+	}
+	builder.closeTag("ul")
+	builder.closeTag("div")
+}
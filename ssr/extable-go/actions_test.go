@@ -0,0 +1,72 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestActionsColumn(t *testing.T) {
+	schema, err := NewSchema[exportRow]().
+		String("name").
+		Actions("menu",
+			Action[exportRow]{ID: "edit", Label: "Edit", Icon: SafeHTML(`<svg></svg>`)},
+			Action[exportRow]{ID: "delete", Label: "Delete", VisibleIf: func(row exportRow) bool { return !row.Active }},
+		).
+		Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	schema.RowID = func(row exportRow) string { return row.Name }
+	if err := schema.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	result, err := RenderTableHTML([]exportRow{{Name: "a", Active: true}, {Name: "b"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	for _, want := range []string{
+		`<button class="extable-actions-toggle" type="button" aria-haspopup="menu" aria-expanded="false" aria-label="Actions">⋮</button>`,
+		`<ul class="extable-actions-menu" role="menu" hidden="">`,
+		`data-action="edit" data-row-id="a"><span class="extable-action-icon" aria-hidden="true"><svg></svg></span>Edit</button>`,
+		`data-action="delete" data-row-id="b">Delete</button>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("missing %s: %s", want, html)
+		}
+	}
+	if strings.Contains(html, `data-action="delete" data-row-id="a"`) {
+		t.Fatalf("VisibleIf should hide delete for active rows: %s", html)
+	}
+	if strings.Contains(html, "extable-editable\" data-col-key=\"menu\"") || len(result.Metadata.Warnings) != 0 {
+		t.Fatalf("actions column should be readonly without warnings: %s %v", html, result.Metadata.Warnings)
+	}
+
+	schema.Columns[1].Actions = []Action[exportRow]{{Label: "x"}}
+	if err := schema.Validate(); err == nil {
+		t.Fatalf("expected action without ID to be rejected")
+	}
+}
+
+func TestActionsColumnCached(t *testing.T) {
+	allowDelete := true
+	schema := Schema[exportRow]{Columns: []Column[exportRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "menu", Type: ColumnTypeActions, Actions: []Action[exportRow]{
+			{ID: "delete", Label: "Delete", VisibleIf: func(exportRow) bool { return allowDelete }},
+		}},
+	}}
+	cache := NewMemoryCache()
+	data := []exportRow{{Name: "a"}}
+	if _, err := RenderCached(cache, "menu", "v1", data, schema, Options{}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	allowDelete = false
+	result, err := RenderCached(cache, "menu", "v1", data, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if result.Metadata.CacheHits != 0 || strings.Contains(result.HTML, `data-action="delete"`) {
+		t.Fatalf("hidden action served from cache: %d hits: %s", result.Metadata.CacheHits, result.HTML)
+	}
+}
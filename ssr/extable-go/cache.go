@@ -0,0 +1,165 @@
+package extable
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Cache stores rendered row fragments for RenderCached. Implementations must
+// be safe for concurrent use.
+type Cache interface {
+	Get(key string) (string, bool)
+	Set(key string, html string)
+}
+
+// MemoryCache is an unbounded in-process Cache.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]string)}
+}
+
+func (c *MemoryCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	html, ok := c.entries[key]
+	return html, ok
+}
+
+func (c *MemoryCache) Set(key string, html string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = html
+}
+
+type rowCache struct {
+	cache  Cache
+	prefix string
+}
+
+// RenderCached renders like RenderTableHTML but reuses row markup from cache
+// when a row's content hash and position are unchanged. key names the table
+// and version must change whenever the schema, options or hooks change in a
+// way that affects row output. Rows that raise warnings or are truncated are
+// always rendered fresh so Metadata stays complete. Row keys cover every field
+// of the row, Schema.RowID, Schema.DetailHTML, the CSRF token and the
+// Highlight term; Hooks output cannot be
+// keyed, so the cache is not used when Options.Hooks is set.
+func RenderCached[T any](cache Cache, key string, version string, data []T, schema Schema[T], opts Options) (Result, error) {
+	opts.rowCache = &rowCache{cache: cache, prefix: key + "\x00" + version + "\x00"}
+	return RenderTableHTML(data, schema, opts)
+}
+
+func (r *tableRenderer[T]) rowCacheKey(builder *htmlBuilder, rowIndex int, hash string, node *treeNode) string {
+	var sb strings.Builder
+	sb.WriteString(r.opts.rowCache.prefix)
+	sb.WriteString(strconv.Itoa(len(builder.stack)))
+	sb.WriteString("\x00")
+	sb.WriteString(strconv.Itoa(rowIndex))
+	if node != nil {
+		sb.WriteString("\x00")
+		sb.WriteString(strconv.Itoa(node.depth))
+		sb.WriteString(strconv.FormatBool(node.hasChildren))
+	}
+	// Auto-scaled heatmaps and data bars depend on the whole column.
+	for _, rng := range r.ranges {
+		sb.WriteString("\x00")
+		sb.WriteString(strconv.FormatFloat(rng.min, 'g', -1, 64))
+		sb.WriteString(",")
+		sb.WriteString(strconv.FormatFloat(rng.max, 'g', -1, 64))
+	}
+	sb.WriteString("\x00")
+	sb.WriteString(r.stripeClass())
+	if r.rowHidden() {
+		sb.WriteString("\x00hidden")
+	}
+	if r.opts.KeyboardNav {
+		sb.WriteString("\x00")
+		sb.WriteString(strconv.Itoa(r.position))
+	}
+	// The CSRF token and highlight term change per request and user.
+	if csrf := r.opts.CSRF; csrf != nil {
+		sb.WriteString("\x00csrf\x1f")
+		sb.WriteString(csrf.fieldName())
+		sb.WriteString("\x1f")
+		sb.WriteString(csrf.Token)
+	}
+	if h := r.opts.Highlight; h != nil && h.Term != "" {
+		sb.WriteString("\x00highlight\x1f")
+		sb.WriteString(strconv.FormatBool(h.CaseInsensitive))
+		sb.WriteString("\x1f")
+		sb.WriteString(h.Term)
+	}
+	sb.WriteString("\x00")
+	sb.WriteString(hash)
+	return sb.String()
+}
+
+// rowInputsKey covers row output that does not come from column values:
+// class rules, href templates and the _readonly field read any field of the
+// row, and RowID and DetailHTML are caller functions.
+func (r *tableRenderer[T]) rowInputsKey(row T) string {
+	h := fnv.New64a()
+	env := r.getter.rowEnv(row)
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s\x1f%v\x1e", key, env[key])
+	}
+	if r.rowID != nil {
+		fmt.Fprintf(h, "\x00id\x1f%s", r.rowID(row))
+	}
+	if r.detail != nil {
+		fmt.Fprintf(h, "\x00detail\x1f%s", r.detail(row))
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+func (r *tableRenderer[T]) renderCachedRow(builder *htmlBuilder, rowIndex int, row T, node *treeNode) {
+	if r.opts.Hooks != nil {
+		r.renderRowMarkup(builder, rowIndex, row, node)
+		return
+	}
+	cache := r.opts.rowCache.cache
+	hash := r.rowHash(row)
+	key := r.rowCacheKey(builder, rowIndex, hash, node) + "\x00" + r.rowInputsKey(row)
+	key += "\x00" + r.actionsKey(row)
+	if r.rowReadonlyReason != nil {
+		key += "\x00" + r.rowReadonlyReason(row)
+	}
+	if len(r.opts.CellErrors) > 0 || r.dirty != nil {
+		key += "\x00" + r.cellStateKey(rowIndex, row)
+	}
+	if r.opts.PreviousValues != nil && r.rowID != nil {
+		key += "\x00" + snapshotKey(r.opts.PreviousValues[r.rowID(row)])
+	}
+	if html, ok := cache.Get(key); ok {
+		if r.opts.EmitRowHash {
+			r.hashes = append(r.hashes, hash)
+		}
+		r.cacheHits++
+		r.observeRowWidths(row)
+		builder.rawBlock(html)
+		return
+	}
+	warnings, truncated := len(r.warnings), r.truncated
+	fragment := newHTMLBuilder(builder.indent, len(builder.stack))
+	fragment.classPrefix = builder.classPrefix
+	r.renderRowMarkup(fragment, rowIndex, row, node)
+	html := fragment.string()
+	builder.attrProblems = append(builder.attrProblems, fragment.attrProblems...)
+	if len(r.warnings) == warnings && r.truncated == truncated && len(fragment.attrProblems) == 0 {
+		cache.Set(key, html)
+	}
+	builder.rawBlock(html)
+}
@@ -0,0 +1,111 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderCached(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}, {Key: "age", Type: ColumnTypeInt}}}
+	cache := NewMemoryCache()
+	data := []sampleRow{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 40}}
+	opts := Options{Indent: "  "}
+
+	first, err := RenderCached(cache, "people", "v1", data, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if first.Metadata.CacheHits != 0 {
+		t.Fatalf("expected a cold cache, got %d hits", first.Metadata.CacheHits)
+	}
+	plain, err := RenderTableHTML(data, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if first.HTML != plain.HTML {
+		t.Fatalf("cached render differs from plain render:\n%s\n%s", first.HTML, plain.HTML)
+	}
+
+	data[1].Age = 41
+	second, err := RenderCached(cache, "people", "v1", data, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if second.Metadata.CacheHits != 1 || !strings.Contains(second.HTML, ">41</td>") {
+		t.Fatalf("expected one hit and the updated row, got %d hits: %s", second.Metadata.CacheHits, second.HTML)
+	}
+
+	third, err := RenderCached(cache, "people", "v2", data, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if third.Metadata.CacheHits != 0 {
+		t.Fatalf("expected a new version to miss, got %d hits", third.Metadata.CacheHits)
+	}
+}
+
+type lockableRow struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Locked bool   `json:"_readonly"`
+}
+
+func TestRenderCachedRowInputs(t *testing.T) {
+	schema := Schema[lockableRow]{
+		Columns:    []Column[lockableRow]{{Key: "name", Type: ColumnTypeString}},
+		RowID:      func(row lockableRow) string { return row.ID },
+		DetailHTML: func(row lockableRow) SafeHTML { return SafeHTML("detail " + row.ID) },
+	}
+	cache := NewMemoryCache()
+	opts := Options{EmitCellCoordinates: true}
+	if _, err := RenderCached(cache, "rows", "v1", []lockableRow{{ID: "1", Name: "Alice"}}, schema, opts); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	second, err := RenderCached(cache, "rows", "v1", []lockableRow{{ID: "2", Name: "Alice", Locked: true}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if second.Metadata.CacheHits != 0 || !strings.Contains(second.HTML, `data-row-id="2"`) || !strings.Contains(second.HTML, "extable-readonly") || !strings.Contains(second.HTML, "detail 2") {
+		t.Fatalf("row inputs outside column values should miss the cache, got %d hits: %s", second.Metadata.CacheHits, second.HTML)
+	}
+
+	calls := 0
+	opts.Hooks = &Hooks{AfterRow: func(RowContext) SafeHTML { calls++; return "" }}
+	for i := 0; i < 2; i++ {
+		result, err := RenderCached(cache, "rows", "v1", []lockableRow{{ID: "2", Name: "Alice"}}, schema, opts)
+		if err != nil || result.Metadata.CacheHits != 0 {
+			t.Fatalf("hooks should bypass the cache: %v %d", err, result.Metadata.CacheHits)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected hooks to run on every render, got %d calls", calls)
+	}
+}
+
+func TestRenderCachedPerRequestOptions(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeButton}}}
+	cache := NewMemoryCache()
+	data := []sampleRow{{Name: "Approve"}}
+	if _, err := RenderCached(cache, "rows", "v1", data, schema, Options{CSRF: &CSRF{Token: "alice-token"}}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	second, err := RenderCached(cache, "rows", "v1", data, schema, Options{CSRF: &CSRF{Token: "bob-token"}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Contains(second.HTML, "alice-token") || !strings.Contains(second.HTML, `value="bob-token"`) {
+		t.Fatalf("cached row leaked another request's CSRF token: %s", second.HTML)
+	}
+
+	schema.Columns[0].Type = ColumnTypeString
+	if _, err := RenderCached(cache, "rows", "v1", data, schema, Options{Highlight: &Highlight{Term: "App"}}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	third, err := RenderCached(cache, "rows", "v1", data, schema, Options{Highlight: &Highlight{Term: "rove"}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if third.Metadata.CacheHits != 0 || !strings.Contains(third.HTML, "rove</mark>") {
+		t.Fatalf("cached row reused another query's highlight: %s", third.HTML)
+	}
+}
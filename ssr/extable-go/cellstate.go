@@ -0,0 +1,69 @@
+package extable
+
+import "strings"
+
+// CellRef names a cell. RowKey is the Schema.RowID value when RowID is set
+// and the row index otherwise, as in CellEdit and FormMode field names.
+type CellRef struct {
+	RowKey string
+	ColKey string
+}
+
+func (r *tableRenderer[T]) cellError(rowIndex int, row T, col Column[T]) (string, bool) {
+	if len(r.opts.CellErrors) == 0 {
+		return "", false
+	}
+	message, ok := r.opts.CellErrors[CellRef{RowKey: r.rowKey(rowIndex, row), ColKey: col.Key}]
+	return message, ok
+}
+
+func dirtySet(cells []CellRef) map[CellRef]bool {
+	if len(cells) == 0 {
+		return nil
+	}
+	set := make(map[CellRef]bool, len(cells))
+	for _, cell := range cells {
+		set[cell] = true
+	}
+	return set
+}
+
+func (r *tableRenderer[T]) isDirty(rowIndex int, row T, col Column[T]) bool {
+	return r.dirty != nil && r.dirty[CellRef{RowKey: r.rowKey(rowIndex, row), ColKey: col.Key}]
+}
+
+// cellStateKey summarizes the per-cell options that apply to a row, for the
+// row cache key.
+func (r *tableRenderer[T]) cellStateKey(rowIndex int, row T) string {
+	var sb strings.Builder
+	for _, col := range r.columns {
+		if message, ok := r.cellError(rowIndex, row, col); ok {
+			sb.WriteString(col.Key)
+			sb.WriteString("=")
+			sb.WriteString(message)
+			sb.WriteString("\x00")
+		}
+		if r.isDirty(rowIndex, row, col) {
+			sb.WriteString(col.Key)
+			sb.WriteString("\x00dirty\x00")
+		}
+	}
+	return sb.String()
+}
+
+// readonlyReason prefers the row's reason, which is the more specific lock.
+func (r *tableRenderer[T]) readonlyReason(col Column[T], rowReadonly bool) string {
+	if rowReadonly && r.rowReason != "" {
+		return r.rowReason
+	}
+	return col.ReadonlyReason
+}
+
+func hasAttr(attrs []string, name string) bool {
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if attrs[i] == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCellErrors(t *testing.T) {
+	schema := Schema[exportRow]{Columns: []Column[exportRow]{
+		{Key: "id", Type: ColumnTypeInt},
+		{Key: "name", Type: ColumnTypeString, MaxChars: 3},
+	}}
+	rows := []exportRow{{ID: 1, Name: "a"}, {ID: 2, Name: "abcdef"}}
+	opts := Options{CellErrors: map[CellRef]string{
+		{RowKey: "0", ColKey: "id"}:   "must be even",
+		{RowKey: "1", ColKey: "name"}: "too long",
+	}}
+	result, err := RenderTableHTML(rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	if !strings.Contains(html, `aria-invalid="true" data-error="must be even" title="must be even">1<span class="extable-error-message">must be even</span></td>`) {
+		t.Fatalf("missing inline error: %s", html)
+	}
+	if strings.Count(html, "extable-invalid") != 2 {
+		t.Fatalf("expected two invalid cells: %s", html)
+	}
+	if !strings.Contains(html, `title="abcdef"`) || len(result.Metadata.Warnings) != 0 {
+		t.Fatalf("truncation title should win without duplicate-attribute warnings: %s %v", html, result.Metadata.Warnings)
+	}
+
+	schema.RowID = func(row exportRow) string { return row.Name }
+	opts.CellErrors = map[CellRef]string{{RowKey: "a", ColKey: "id"}: "bad"}
+	cache := NewMemoryCache()
+	if _, err := RenderCached(cache, "t", "v", rows, schema, Options{}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	cached, err := RenderCached(cache, "t", "v", rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(cached.HTML, `data-error="bad"`) {
+		t.Fatalf("errors must bypass cached rows: %s", cached.HTML)
+	}
+}
+
+func TestDirtyCells(t *testing.T) {
+	schema := Schema[exportRow]{
+		Columns: []Column[exportRow]{
+			{Key: "id", Type: ColumnTypeInt},
+			{Key: "name", Type: ColumnTypeString},
+		},
+		RowID: func(row exportRow) string { return row.Name },
+	}
+	rows := []exportRow{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	opts := Options{DirtyCells: []CellRef{{RowKey: "b", ColKey: "id"}}}
+	result, err := RenderTableHTML(rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Count(result.HTML, "extable-dirty") != 1 || !strings.Contains(result.HTML, `data-dirty="">2</td>`) {
+		t.Fatalf("expected one dirty cell: %s", result.HTML)
+	}
+
+	cache := NewMemoryCache()
+	if _, err := RenderCached(cache, "t", "v", rows, schema, Options{}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	cached, err := RenderCached(cache, "t", "v", rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(cached.HTML, "extable-dirty") {
+		t.Fatalf("dirty cells must bypass cached rows: %s", cached.HTML)
+	}
+}
+
+func TestReadonlyReasons(t *testing.T) {
+	schema := Schema[exportRow]{
+		Columns: []Column[exportRow]{
+			{Key: "id", Type: ColumnTypeInt, Readonly: true, ReadonlyReason: "assigned by the server"},
+			{Key: "name", Type: ColumnTypeString},
+		},
+		RowReadonlyReason: func(row exportRow) string {
+			if row.Active {
+				return "published rows are locked"
+			}
+			return ""
+		},
+	}
+	result, err := RenderTableHTML([]exportRow{{ID: 1, Name: "a"}, {ID: 2, Name: "b", Active: true}}, schema, Options{EmitState: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	if !strings.Contains(html, `data-col-key="id" data-readonly-reason="assigned by the server" title="assigned by the server">1</td>`) {
+		t.Fatalf("missing column reason: %s", html)
+	}
+	if strings.Count(html, `data-readonly-reason="published rows are locked"`) != 2 {
+		t.Fatalf("expected the row reason on both cells of the locked row: %s", html)
+	}
+	if strings.Count(html, "extable-editable") != 1 {
+		t.Fatalf("a row reason should make the row readonly: %s", html)
+	}
+	if !strings.Contains(html, `"readonlyReason":"assigned by the server"`) {
+		t.Fatalf("missing reason in state: %s", html)
+	}
+}
+
+func TestReadonlyReasonCached(t *testing.T) {
+	locked := map[string]string{}
+	schema := Schema[exportRow]{
+		Columns:           []Column[exportRow]{{Key: "name", Type: ColumnTypeString}},
+		RowReadonlyReason: func(row exportRow) string { return locked[row.Name] },
+	}
+	cache := NewMemoryCache()
+	data := []exportRow{{Name: "a"}}
+	if _, err := RenderCached(cache, "locks", "v1", data, schema, Options{}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	locked["a"] = "Closed period"
+	result, err := RenderCached(cache, "locks", "v1", data, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if result.Metadata.CacheHits != 0 || !strings.Contains(result.HTML, `data-readonly-reason="Closed period"`) {
+		t.Fatalf("locked row served from cache: %d hits: %s", result.Metadata.CacheHits, result.HTML)
+	}
+}
@@ -0,0 +1,24 @@
+package extable
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// checksum returns the hex SHA-256 of html after dropping the named
+// attributes, so volatile values such as per-request ids do not change it.
+func checksum(html string, ignoreAttrs []string) string {
+	if len(ignoreAttrs) > 0 {
+		names := make([]string, len(ignoreAttrs))
+		for i, name := range ignoreAttrs {
+			names[i] = regexp.QuoteMeta(name)
+		}
+		// The builder always writes double-quoted, escaped attribute values.
+		attr := regexp.MustCompile(` (?:` + strings.Join(names, "|") + `)="[^"]*"`)
+		html = attr.ReplaceAllString(html, "")
+	}
+	sum := sha256.Sum256([]byte(html))
+	return hex.EncodeToString(sum[:])
+}
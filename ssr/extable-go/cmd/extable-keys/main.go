@@ -0,0 +1,161 @@
+// Command extable-keys generates typed column key constants for a row struct,
+// using the same extable/json/field-name rules the renderer uses to resolve
+// keys. Typical use:
+//
+//	//go:generate go run github.com/shibukawayoshiki/extable/ssr/extable-go/cmd/extable-keys -type User
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	typeName := flag.String("type", "", "row struct type name (required)")
+	prefix := flag.String("prefix", "", `constant name prefix; "Col"+type when empty`)
+	output := flag.String("output", "", "output file; <type>_keys.go in the package directory when empty")
+	flag.Parse()
+	if *typeName == "" {
+		fmt.Fprintln(os.Stderr, "extable-keys: -type is required")
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	src, err := generate(dir, *typeName, *prefix)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "extable-keys:", err)
+		os.Exit(1)
+	}
+	path := *output
+	if path == "" {
+		path = filepath.Join(dir, strings.ToLower(*typeName)+"_keys.go")
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "extable-keys:", err)
+		os.Exit(1)
+	}
+}
+
+type keyField struct {
+	field string
+	key   string
+}
+
+func generate(dir, typeName, prefix string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	for name, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			if st := findStruct(file, typeName); st != nil {
+				if prefix == "" {
+					prefix = "Col" + typeName
+				}
+				return render(name, typeName, prefix, structKeys(st))
+			}
+		}
+	}
+	return nil, fmt.Errorf("struct type %s not found in %s", typeName, dir)
+}
+
+func findStruct(file *ast.File, typeName string) *ast.StructType {
+	var found *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != typeName {
+			return found == nil
+		}
+		if st, ok := spec.Type.(*ast.StructType); ok {
+			found = st
+		}
+		return false
+	})
+	return found
+}
+
+// structKeys mirrors newFieldGetter: the extable tag wins over the json tag,
+// which wins over the field name; "-" and unexported fields are skipped and
+// the first field claiming a key keeps it.
+func structKeys(st *ast.StructType) []keyField {
+	keys := make([]keyField, 0)
+	seen := make(map[string]bool)
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted)
+			}
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{embeddedName(field.Type)}
+		}
+		for _, name := range names {
+			if name == nil || !name.IsExported() {
+				continue
+			}
+			key := tag.Get("extable")
+			if key == "" {
+				key, _, _ = strings.Cut(tag.Get("json"), ",")
+			}
+			if key == "" {
+				key = name.Name
+			}
+			if key == "-" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, keyField{field: name.Name, key: key})
+		}
+	}
+	return keys
+}
+
+func embeddedName(expr ast.Expr) *ast.Ident {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel
+	}
+	return nil
+}
+
+func render(pkgName, typeName, prefix string, keys []keyField) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by extable-keys; DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+	buf.WriteString("import extable \"github.com/shibukawayoshiki/extable/ssr/extable-go\"\n\n")
+	buf.WriteString("const (\n")
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "\t%s%s extable.ColumnKey = %s\n", prefix, k.field, strconv.Quote(k.key))
+	}
+	buf.WriteString(")\n\n")
+	fmt.Fprintf(&buf, "// %sKeys returns the column keys of %s in field order.\n", typeName, typeName)
+	fmt.Fprintf(&buf, "func %sKeys() []extable.ColumnKey {\n\treturn []extable.ColumnKey{", typeName)
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(prefix + k.field)
+	}
+	buf.WriteString("}\n}\n")
+	return format.Source(buf.Bytes())
+}
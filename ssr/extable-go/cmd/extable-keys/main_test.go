@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	src := "package models\n\ntype User struct {\n" +
+		"\tName  string `extable:\"name\" json:\"full_name\"`\n" +
+		"\tEmail string `json:\"email,omitempty\"`\n" +
+		"\tAge   int\n" +
+		"\tSecret string `json:\"-\"`\n" +
+		"\tnote  string\n" +
+		"}\n"
+	if err := os.WriteFile(filepath.Join(dir, "user.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	out, err := generate(dir, "User", "")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{
+		"package models",
+		`ColUserName  extable.ColumnKey = "name"`,
+		`ColUserEmail extable.ColumnKey = "email"`,
+		`ColUserAge   extable.ColumnKey = "Age"`,
+		"return []extable.ColumnKey{ColUserName, ColUserEmail, ColUserAge}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Secret") || strings.Contains(got, "note") {
+		t.Fatalf("expected skipped fields to be absent:\n%s", got)
+	}
+	if _, err := generate(dir, "Missing", ""); err == nil {
+		t.Fatalf("expected error for missing type")
+	}
+}
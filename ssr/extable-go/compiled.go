@@ -0,0 +1,112 @@
+package extable
+
+import "context"
+
+// Renderer renders one schema repeatedly. NewRenderer does the schema work
+// once (field resolution, template and expression checks, href parsing, the
+// schema hash); Render then only handles the data. A Renderer is safe for
+// concurrent use as long as Options hooks and callbacks are. With
+// Schema.DynamicColumns the schema work is repeated on every render. Use
+// RenderView for filters that depend on the requesting user.
+type Renderer[T any] struct {
+	compiled   *compiledSchema[T]
+	opts       Options
+	schemaHash string
+	// dynamic is the schema to recompile per render when it has DynamicColumns.
+	dynamic *Schema[T]
+}
+
+func NewRenderer[T any](schema Schema[T], opts Options) (*Renderer[T], error) {
+	if err := schema.ValidateOptions(opts); err != nil {
+		return nil, err
+	}
+	schema.Columns = append([]Column[T](nil), schema.Columns...)
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	renderer := &Renderer[T]{compiled: compiled, opts: opts, schemaHash: SchemaHash(schema)}
+	if schema.DynamicColumns != nil {
+		renderer.dynamic = &schema
+	}
+	return renderer, nil
+}
+
+// View narrows a single render to what one viewer may see, so a shared
+// Renderer can serve every user. Its filters apply on top of the schema's.
+type View[T any] struct {
+	// RowFilter skips rows for which it returns false, like Schema.RowFilter.
+	RowFilter func(T) bool
+	// ColumnVisible hides columns for which it returns false, like
+	// Schema.ColumnVisible.
+	ColumnVisible func(Column[T]) bool
+}
+
+// Render is equivalent to RenderTableHTML with the renderer's schema and options.
+func (r *Renderer[T]) Render(data []T) (Result, error) {
+	return r.render(r.opts, data)
+}
+
+// RenderContext is Render with the cancellation behaviour of RenderTableHTMLContext.
+func (r *Renderer[T]) RenderContext(ctx context.Context, data []T) (Result, error) {
+	opts := r.opts
+	opts.ctx = ctx
+	return r.render(opts, data)
+}
+
+// RenderView is RenderContext narrowed by view.
+func (r *Renderer[T]) RenderView(ctx context.Context, data []T, view View[T]) (Result, error) {
+	opts := r.opts
+	opts.ctx = ctx
+	return r.withView(view).render(opts, data)
+}
+
+// withView returns a copy of r whose schema also applies the view's filters.
+func (r *Renderer[T]) withView(view View[T]) *Renderer[T] {
+	narrowed := *r
+	compiled := *r.compiled
+	compiled.schema.RowFilter = bothFilters(compiled.schema.RowFilter, view.RowFilter)
+	compiled.schema.ColumnVisible = bothFilters(compiled.schema.ColumnVisible, view.ColumnVisible)
+	narrowed.compiled = &compiled
+	if view.ColumnVisible != nil {
+		narrowed.schemaHash = SchemaHash(compiled.schema)
+	}
+	if r.dynamic != nil {
+		dynamic := *r.dynamic
+		dynamic.RowFilter = compiled.schema.RowFilter
+		dynamic.ColumnVisible = compiled.schema.ColumnVisible
+		narrowed.dynamic = &dynamic
+	}
+	return &narrowed
+}
+
+// bothFilters returns a filter accepting what both a and b accept; nil
+// filters accept everything.
+func bothFilters[V any](a, b func(V) bool) func(V) bool {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return func(v V) bool { return a(v) && b(v) }
+}
+
+func (r *Renderer[T]) render(opts Options, data []T) (Result, error) {
+	if r.dynamic != nil {
+		schema := r.dynamic.withDynamicColumns(data)
+		compiled, err := compileSchema(schema)
+		if err != nil {
+			return Result{}, err
+		}
+		r = &Renderer[T]{compiled: compiled, opts: r.opts, schemaHash: SchemaHash(schema)}
+	}
+	schemaHash := func() string { return r.schemaHash }
+	return renderResult(opts, schemaHash, func(builder *htmlBuilder) (Metadata, error) {
+		table, data, nodes, err := r.compiled.prepare(data, &opts)
+		if err != nil {
+			return Metadata{}, err
+		}
+		return table.renderTable(builder, data, nodes)
+	})
+}
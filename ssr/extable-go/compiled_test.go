@@ -0,0 +1,74 @@
+package extable
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRenderer(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt, Expr: "age * 2"},
+	}}
+	opts := Options{WrapWithRoot: true, EmitState: true, Sort: []SortSpec{{Key: "name"}}}
+	renderer, err := NewRenderer(schema, opts)
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+	schema.Columns[0].Header = "changed after compile"
+
+	rows := []sampleRow{{Name: "b", Age: 2}, {Name: "a", Age: 1}}
+	var wg sync.WaitGroup
+	results := make([]Result, 4)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := renderer.Render(rows)
+			if err != nil {
+				t.Errorf("render failed: %v", err)
+			}
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+	schema.Columns[0].Header = ""
+	expected, err := RenderTableHTML(rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, result := range results {
+		if result.HTML != expected.HTML || result.Metadata.Checksum != expected.Metadata.Checksum {
+			t.Fatalf("renderer output differs from RenderTableHTML:\n%s\n%s", result.HTML, expected.HTML)
+		}
+	}
+
+	if _, err := NewRenderer(Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "x", Type: ColumnTypeInt, Expr: "("}}}, Options{}); err == nil {
+		t.Fatalf("expected compile errors from NewRenderer")
+	}
+}
+
+func TestRendererView(t *testing.T) {
+	schema := Schema[sampleRow]{
+		Columns:   []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}},
+		RowFilter: func(row sampleRow) bool { return row.Age > 0 },
+	}
+	renderer, err := NewRenderer(schema, Options{})
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+	rows := []sampleRow{{Name: "alice", Age: 1}, {Name: "bob", Age: 2}, {Name: "nobody"}}
+	own, err := renderer.RenderView(context.Background(), rows, View[sampleRow]{RowFilter: func(row sampleRow) bool { return row.Name == "alice" }})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(own.HTML, "alice") || strings.Contains(own.HTML, "bob") || own.Metadata.FilteredCount != 2 {
+		t.Fatalf("expected the view filter on top of the schema filter: %+v %s", own.Metadata, own.HTML)
+	}
+	all, err := renderer.Render(rows)
+	if err != nil || !strings.Contains(all.HTML, "bob") || strings.Contains(all.HTML, "nobody") {
+		t.Fatalf("expected the view not to change the shared renderer: %v %s", err, all.HTML)
+	}
+}
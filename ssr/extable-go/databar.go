@@ -0,0 +1,31 @@
+package extable
+
+import "math"
+
+func renderDataBar(builder *htmlBuilder, value any, text string, spec *DataBarSpec, format *Format, colRange numericRange) {
+	builder.openTag("div", "class", "extable-databar")
+	if number, ok := numericValue(value); ok && isFinite(number) {
+		limit := math.Max(math.Abs(colRange.min), math.Abs(colRange.max))
+		if spec.Max != nil && isFinite(*spec.Max) {
+			limit = math.Abs(*spec.Max)
+		}
+		ratio := 0.0
+		if limit > 0 {
+			ratio = math.Min(math.Abs(number)/limit, 1)
+		}
+		style := map[string]string{"width": svgNumber(ratio*100) + "%"}
+		if color, ok := cssColor(spec.Color); ok {
+			style["background-color"] = color
+		}
+		classes := "extable-databar-fill"
+		if number < 0 {
+			classes += " extable-databar-negative"
+		}
+		builder.openTag("div", "class", classes, "style", styleString(style))
+		builder.closeTag("div")
+	}
+	builder.openTag("span", "class", "extable-databar-value")
+	renderNumberText(builder, text, format)
+	builder.closeTag("span")
+	builder.closeTag("div")
+}
@@ -0,0 +1,177 @@
+package extable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateStyle formats dates with the month and weekday names of
+// Options.Locale, for layouts like "2 January 2006" that Go cannot localize.
+type DateStyle string
+
+const (
+	DateStyleShort  DateStyle = "short"
+	DateStyleMedium DateStyle = "medium"
+	DateStyleLong   DateStyle = "long"
+	DateStyleFull   DateStyle = "full"
+)
+
+// DateNames holds one locale's names and per-style patterns. Patterns use
+// {yyyy} {yy} {M} {MM} {MMM} {MMMM} {d} {dd} {EEEE}; MMM is the short month
+// name and EEEE the weekday.
+type DateNames struct {
+	Months      [12]string
+	ShortMonths [12]string
+	Weekdays    [7]string
+	Patterns    map[DateStyle]string
+}
+
+var (
+	dateNamesMu sync.RWMutex
+	dateNames   = map[string]DateNames{
+		"en": {
+			Months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+			Weekdays:    [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			Patterns: map[DateStyle]string{
+				DateStyleShort:  "{M}/{d}/{yy}",
+				DateStyleMedium: "{MMM} {d}, {yyyy}",
+				DateStyleLong:   "{MMMM} {d}, {yyyy}",
+				DateStyleFull:   "{EEEE}, {MMMM} {d}, {yyyy}",
+			},
+		},
+		"ja": {
+			Months:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			ShortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			Weekdays:    [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+			Patterns: map[DateStyle]string{
+				DateStyleShort:  "{yyyy}/{MM}/{dd}",
+				DateStyleMedium: "{yyyy}/{MM}/{dd}",
+				DateStyleLong:   "{yyyy}年{M}月{d}日",
+				DateStyleFull:   "{yyyy}年{M}月{d}日{EEEE}",
+			},
+		},
+		"de": {
+			Months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+			ShortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+			Weekdays:    [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+			Patterns: map[DateStyle]string{
+				DateStyleShort:  "{dd}.{MM}.{yy}",
+				DateStyleMedium: "{dd}.{MM}.{yyyy}",
+				DateStyleLong:   "{d}. {MMMM} {yyyy}",
+				DateStyleFull:   "{EEEE}, {d}. {MMMM} {yyyy}",
+			},
+		},
+		"fr": {
+			Months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+			ShortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+			Weekdays:    [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+			Patterns: map[DateStyle]string{
+				DateStyleShort:  "{dd}/{MM}/{yyyy}",
+				DateStyleMedium: "{d} {MMM} {yyyy}",
+				DateStyleLong:   "{d} {MMMM} {yyyy}",
+				DateStyleFull:   "{EEEE} {d} {MMMM} {yyyy}",
+			},
+		},
+		"es": {
+			Months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+			ShortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+			Weekdays:    [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+			Patterns: map[DateStyle]string{
+				DateStyleShort:  "{d}/{M}/{yy}",
+				DateStyleMedium: "{d} {MMM} {yyyy}",
+				DateStyleLong:   "{d} de {MMMM} de {yyyy}",
+				DateStyleFull:   "{EEEE}, {d} de {MMMM} de {yyyy}",
+			},
+		},
+	}
+)
+
+// RegisterDateNames adds or replaces the names used for locale, a BCP 47 tag
+// such as "pt" or "pt-BR". Lookups try the full tag, then its language.
+// Built in are en, ja, de, fr and es; other locales fall back to en.
+func RegisterDateNames(locale string, names DateNames) {
+	dateNamesMu.Lock()
+	defer dateNamesMu.Unlock()
+	dateNames[strings.ToLower(locale)] = names
+}
+
+func lookupDateNames(locale string) DateNames {
+	dateNamesMu.RLock()
+	defer dateNamesMu.RUnlock()
+	tag := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if names, ok := dateNames[tag]; ok {
+		return names
+	}
+	if base, _, ok := strings.Cut(tag, "-"); ok {
+		if names, ok := dateNames[base]; ok {
+			return names
+		}
+	}
+	return dateNames["en"]
+}
+
+func validDateStyle(style DateStyle) bool {
+	switch style {
+	case DateStyleShort, DateStyleMedium, DateStyleLong, DateStyleFull:
+		return true
+	}
+	return false
+}
+
+func checkDateStyles[T any](columns []Column[T]) error {
+	for _, col := range columns {
+		if col.Format != nil && col.Format.DateStyle != "" && !validDateStyle(col.Format.DateStyle) {
+			return &SchemaError{ColKey: col.Key, Message: fmt.Sprintf("has unknown date style %q", col.Format.DateStyle)}
+		}
+	}
+	return nil
+}
+
+func formatDateStyle(t time.Time, style DateStyle, locale string) string {
+	names := lookupDateNames(locale)
+	pattern, ok := names.Patterns[style]
+	if !ok {
+		pattern = dateNames["en"].Patterns[style]
+	}
+	var sb strings.Builder
+	for pattern != "" {
+		start := strings.IndexByte(pattern, '{')
+		end := strings.IndexByte(pattern, '}')
+		if start < 0 || end < start {
+			sb.WriteString(pattern)
+			break
+		}
+		sb.WriteString(pattern[:start])
+		sb.WriteString(dateToken(t, pattern[start+1:end], names))
+		pattern = pattern[end+1:]
+	}
+	return sb.String()
+}
+
+func dateToken(t time.Time, token string, names DateNames) string {
+	switch token {
+	case "yyyy":
+		return strconv.Itoa(t.Year())
+	case "yy":
+		return t.Format("06")
+	case "M":
+		return strconv.Itoa(int(t.Month()))
+	case "MM":
+		return t.Format("01")
+	case "MMM":
+		return names.ShortMonths[t.Month()-1]
+	case "MMMM":
+		return names.Months[t.Month()-1]
+	case "d":
+		return strconv.Itoa(t.Day())
+	case "dd":
+		return t.Format("02")
+	case "EEEE":
+		return names.Weekdays[t.Weekday()]
+	}
+	return "{" + token + "}"
+}
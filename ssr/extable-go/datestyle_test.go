@@ -0,0 +1,48 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDateStyle(t *testing.T) {
+	day := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	cases := []struct {
+		locale string
+		style  DateStyle
+		want   string
+	}{
+		{"", DateStyleShort, "3/5/24"},
+		{"en-US", DateStyleFull, "Tuesday, March 5, 2024"},
+		{"ja-JP", DateStyleLong, "2024年3月5日"},
+		{"de", DateStyleLong, "5. März 2024"},
+		{"fr", DateStyleMedium, "5 mars 2024"},
+		{"es", DateStyleFull, "martes, 5 de marzo de 2024"},
+		{"ko", DateStyleMedium, "Mar 5, 2024"},
+	}
+	for _, c := range cases {
+		if got := formatDateStyle(day, c.style, c.locale); got != c.want {
+			t.Fatalf("%s %s: got %q, want %q", c.locale, c.style, got, c.want)
+		}
+	}
+
+	type event struct {
+		At time.Time `json:"at"`
+	}
+	schema := Schema[event]{Columns: []Column[event]{
+		{Key: "at", Type: ColumnTypeDateTime, Format: &Format{DateStyle: DateStyleLong}},
+	}}
+	result, err := RenderTableHTML([]event{{At: day}}, schema, Options{Locale: "de-AT"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, ">5. März 2024 14:30</td>") {
+		t.Fatalf("unexpected datetime: %s", result.HTML)
+	}
+
+	schema.Columns[0].Format.DateStyle = "huge"
+	if _, err := RenderTableHTML([]event{{At: day}}, schema, Options{}); err == nil {
+		t.Fatalf("expected unknown date style to be rejected")
+	}
+}
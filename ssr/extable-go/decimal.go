@@ -0,0 +1,120 @@
+package extable
+
+import (
+	"math/big"
+	"strings"
+)
+
+// DecimalStringer is implemented by arbitrary-precision decimal types such as
+// shopspring/decimal.Decimal.
+type DecimalStringer interface {
+	String() string
+	StringFixed(places int32) string
+}
+
+// ratDefaultDigits bounds the expansion of non-terminating rationals when no
+// NumberScale is set.
+const ratDefaultDigits = 16
+
+func bigValue(value any) any {
+	switch v := value.(type) {
+	case big.Int:
+		return &v
+	case big.Float:
+		return &v
+	case big.Rat:
+		return &v
+	default:
+		return value
+	}
+}
+
+func formatBigNumber(value any, scale int) (string, bool) {
+	switch v := bigValue(value).(type) {
+	case *big.Int:
+		if v == nil {
+			return "", true
+		}
+		if scale > 0 {
+			return v.String() + "." + strings.Repeat("0", scale), true
+		}
+		return v.String(), true
+	case *big.Float:
+		if v == nil {
+			return "", true
+		}
+		return v.Text('f', scale), true
+	case *big.Rat:
+		if v == nil {
+			return "", true
+		}
+		if scale >= 0 {
+			return v.FloatString(scale), true
+		}
+		if v.IsInt() {
+			return v.Num().String(), true
+		}
+		return trimDecimalZeros(v.FloatString(ratDefaultDigits)), true
+	case DecimalStringer:
+		if scale >= 0 {
+			return v.StringFixed(int32(scale)), true
+		}
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
+
+func formatBigInteger(value any) (string, bool) {
+	switch v := bigValue(value).(type) {
+	case *big.Int:
+		if v == nil {
+			return "", true
+		}
+		return v.String(), true
+	case *big.Float, *big.Rat, DecimalStringer:
+		return formatBigNumber(v, 0)
+	default:
+		return "", false
+	}
+}
+
+func bigNumericValue(value any) (float64, bool) {
+	switch v := bigValue(value).(type) {
+	case *big.Int:
+		if v == nil {
+			return 0, false
+		}
+		f, _ := new(big.Float).SetInt(v).Float64()
+		return f, true
+	case *big.Float:
+		if v == nil {
+			return 0, false
+		}
+		f, _ := v.Float64()
+		return f, true
+	case *big.Rat:
+		if v == nil {
+			return 0, false
+		}
+		f, _ := v.Float64()
+		return f, true
+	case DecimalStringer:
+		f, ok := new(big.Float).SetString(v.String())
+		if !ok {
+			return 0, false
+		}
+		result, _ := f.Float64()
+		return result, true
+	default:
+		return 0, false
+	}
+}
+
+func trimDecimalZeros(text string) string {
+	if !strings.Contains(text, ".") {
+		return text
+	}
+	text = strings.TrimRight(text, "0")
+	return strings.TrimSuffix(text, ".")
+}
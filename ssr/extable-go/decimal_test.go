@@ -0,0 +1,65 @@
+package extable
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+type fixedDecimal struct {
+	units int64
+}
+
+func (d fixedDecimal) String() string {
+	return big.NewRat(d.units, 100).FloatString(2)
+}
+
+func (d fixedDecimal) StringFixed(places int32) string {
+	return big.NewRat(d.units, 100).FloatString(int(places))
+}
+
+type ledgerRow struct {
+	Big   *big.Int     `json:"big"`
+	Float *big.Float   `json:"float"`
+	Ratio *big.Rat     `json:"ratio"`
+	Money fixedDecimal `json:"money"`
+}
+
+func TestFormatArbitraryPrecision(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	precise, _ := new(big.Float).SetPrec(200).SetString("0.1000000000000000000001")
+	scale := 3
+	result, err := RenderTableHTML(
+		[]ledgerRow{{Big: huge, Float: precise, Ratio: big.NewRat(1, 3), Money: fixedDecimal{units: 12345}}},
+		Schema[ledgerRow]{Columns: []Column[ledgerRow]{
+			{Key: "big", Type: ColumnTypeInt},
+			{Key: "float", Type: ColumnTypeNumber, Format: &Format{NumberScale: &scale}},
+			{Key: "ratio", Type: ColumnTypeNumber},
+			{Key: "money", Type: ColumnTypeNumber, Format: &Format{NumberScale: &scale}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{">123456789012345678901234567890<", ">0.100<", ">0.3333333333333333<", ">123.450<"} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s: %s", want, result.HTML)
+		}
+	}
+}
+
+type hostileDecimal struct{}
+
+func (hostileDecimal) String() string                  { return "1); DROP TABLE users; --" }
+func (hostileDecimal) StringFixed(places int32) string { return "1" }
+
+func TestSQLLiteralDecimalText(t *testing.T) {
+	col := Column[ledgerRow]{Key: "money", Type: ColumnTypeNumber}
+	if got := sqlLiteral(fixedDecimal{units: -1234}, col, SQLDialectPostgres); got != "-12.34" {
+		t.Fatalf("plain decimals should stay numeric, got %q", got)
+	}
+	if got := sqlLiteral(hostileDecimal{}, col, SQLDialectPostgres); got != "'1); DROP TABLE users; --'" {
+		t.Fatalf("non-numeric decimal text must be quoted, got %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+// Package delta computes row-level update messages between two renders of an
+// extable, for pushing to clients over SSE or WebSocket.
+package delta
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	extable "github.com/shibukawayoshiki/extable/ssr/extable-go"
+)
+
+type Op string
+
+const (
+	OpAdd    Op = "add"
+	OpUpdate Op = "update"
+	OpRemove Op = "remove"
+)
+
+// Message describes one row change. Index is the row's position in the new
+// data for add and update, and in the old data for remove. HTML holds the
+// rendered <tr> fragment and is empty for remove.
+type Message struct {
+	Op    Op     `json:"op"`
+	RowID string `json:"rowId"`
+	Index int    `json:"index"`
+	HTML  string `json:"html,omitempty"`
+}
+
+// Compute compares old and new by rowID and returns remove messages first
+// (in old order) followed by add and update messages in new order. A row is
+// updated when its rendered fragment changed, which includes moves.
+func Compute[T any](old, new []T, rowID func(T) string, schema extable.Schema[T], opts extable.Options) ([]Message, error) {
+	oldHTML, err := extable.RenderRowFragments(old, schema, opts)
+	if err != nil {
+		return nil, err
+	}
+	newHTML, err := extable.RenderRowFragments(new, schema, opts)
+	if err != nil {
+		return nil, err
+	}
+	oldByID := make(map[string]int, len(old))
+	for i, row := range old {
+		id := rowID(row)
+		if _, dup := oldByID[id]; dup {
+			return nil, fmt.Errorf("%w %q in old data", extable.ErrDuplicateRowID, id)
+		}
+		oldByID[id] = i
+	}
+	newIDs := make(map[string]bool, len(new))
+	for _, row := range new {
+		id := rowID(row)
+		if newIDs[id] {
+			return nil, fmt.Errorf("%w %q in new data", extable.ErrDuplicateRowID, id)
+		}
+		newIDs[id] = true
+	}
+
+	messages := make([]Message, 0)
+	for i, row := range old {
+		if id := rowID(row); !newIDs[id] {
+			messages = append(messages, Message{Op: OpRemove, RowID: id, Index: i})
+		}
+	}
+	for i, row := range new {
+		id := rowID(row)
+		oldIndex, existed := oldByID[id]
+		switch {
+		case !existed:
+			messages = append(messages, Message{Op: OpAdd, RowID: id, Index: i, HTML: newHTML[i]})
+		case oldHTML[oldIndex] != newHTML[i]:
+			messages = append(messages, Message{Op: OpUpdate, RowID: id, Index: i, HTML: newHTML[i]})
+		}
+	}
+	return messages, nil
+}
+
+// WriteSSE writes each message as a server-sent event named extable-delta.
+func WriteSSE(w io.Writer, messages []Message) error {
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: extable-delta\ndata: %s\n\n", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
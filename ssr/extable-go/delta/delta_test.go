@@ -0,0 +1,50 @@
+package delta
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	extable "github.com/shibukawayoshiki/extable/ssr/extable-go"
+)
+
+type item struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCompute(t *testing.T) {
+	schema := extable.Schema[item]{Columns: []extable.Column[item]{{Key: "name", Type: extable.ColumnTypeString}}}
+	old := []item{{ID: "a", Name: "Alpha"}, {ID: "b", Name: "Beta"}, {ID: "c", Name: "Gamma"}}
+	new := []item{{ID: "a", Name: "Alpha"}, {ID: "c", Name: "Gamma!"}, {ID: "d", Name: "Delta"}}
+	messages, err := Compute(old, new, func(row item) string { return row.ID }, schema, extable.Options{})
+	if err != nil {
+		t.Fatalf("compute failed: %v", err)
+	}
+	got := make([]string, len(messages))
+	for i, m := range messages {
+		got[i] = string(m.Op) + ":" + m.RowID
+	}
+	if strings.Join(got, ",") != "remove:b,update:c,add:d" {
+		t.Fatalf("unexpected messages: %v", got)
+	}
+	if !strings.HasPrefix(messages[1].HTML, "<tr>") || !strings.Contains(messages[1].HTML, "Gamma!") {
+		t.Fatalf("expected rendered row fragment, got %s", messages[1].HTML)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSSE(&buf, messages[:1]); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if buf.String() != "event: extable-delta\ndata: {\"op\":\"remove\",\"rowId\":\"b\",\"index\":1}\n\n" {
+		t.Fatalf("unexpected sse output: %q", buf.String())
+	}
+}
+
+func TestComputeDuplicateID(t *testing.T) {
+	schema := extable.Schema[item]{Columns: []extable.Column[item]{{Key: "name", Type: extable.ColumnTypeString}}}
+	rows := []item{{ID: "a"}, {ID: "a"}}
+	if _, err := Compute(nil, rows, func(row item) string { return row.ID }, schema, extable.Options{}); err == nil {
+		t.Fatalf("expected duplicate id error")
+	}
+}
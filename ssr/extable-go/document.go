@@ -0,0 +1,129 @@
+package extable
+
+import (
+	"fmt"
+	"time"
+)
+
+type Document struct {
+	opts     Options
+	sections []documentSection
+	ids      map[string]bool
+}
+
+type Section struct {
+	ID    string
+	Title string
+}
+
+type DocumentResult struct {
+	HTML     string
+	Metadata DocumentMetadata
+}
+
+type DocumentMetadata struct {
+	RowCount int
+	Sections []SectionMetadata
+	Warnings []Warning
+	Checksum string
+}
+
+type SectionMetadata struct {
+	ID       string
+	Title    string
+	Metadata Metadata
+}
+
+type documentSection struct {
+	section  Section
+	html     string
+	metadata Metadata
+}
+
+// NewDocument creates a document whose root wrapper is controlled by opts.
+func NewDocument(opts Options) *Document {
+	return &Document{opts: opts, ids: make(map[string]bool)}
+}
+
+// AddTable renders a table as a new section. The section's WrapWithRoot is
+// ignored because the document owns the root wrapper.
+func AddTable[T any](doc *Document, section Section, data []T, schema Schema[T], opts Options) error {
+	if section.ID == "" {
+		return ErrMissingSectionID
+	}
+	if doc.ids[section.ID] {
+		return fmt.Errorf("%w %q", ErrDuplicateSectionID, section.ID)
+	}
+	// Sections are rendered inside <section> and, optionally, the three root wrapper elements.
+	depth := 1
+	if doc.opts.WrapWithRoot {
+		depth += 3
+	}
+	if opts.IDPrefix == "" {
+		opts.IDPrefix = section.ID
+	}
+	builder := newHTMLBuilder(doc.opts.Indent, depth)
+	builder.classPrefix = opts.ClassPrefix
+	metadata, err := renderTable(builder, data, schema, opts)
+	if err != nil {
+		return err
+	}
+	html := builder.string()
+	metadata.Checksum = checksum(html, opts.ChecksumIgnoreAttrs)
+	doc.ids[section.ID] = true
+	doc.sections = append(doc.sections, documentSection{
+		section:  section,
+		html:     html,
+		metadata: metadata,
+	})
+	return nil
+}
+
+func (d *Document) Render() DocumentResult {
+	start := time.Now()
+	builder := newHTMLBuilder(d.opts.Indent, 0)
+	builder.classPrefix = d.opts.ClassPrefix
+	if d.opts.WrapWithRoot {
+		// Sections may use different schemas, so the shared root carries no schema hash.
+		openRoot(builder, d.opts, "")
+	}
+	metadata := DocumentMetadata{
+		Sections: make([]SectionMetadata, 0, len(d.sections)),
+		Warnings: make([]Warning, 0),
+	}
+	for _, s := range d.sections {
+		builder.openTag("section", "class", "extable-section", "id", s.section.ID)
+		if s.section.Title != "" {
+			builder.openTag("h2", "class", "extable-section-title")
+			builder.text(s.section.Title)
+			builder.closeTag("h2")
+		}
+		builder.rawBlock(s.html)
+		builder.closeTag("section")
+
+		metadata.RowCount += s.metadata.RowCount
+		metadata.Sections = append(metadata.Sections, SectionMetadata{
+			ID:       s.section.ID,
+			Title:    s.section.Title,
+			Metadata: s.metadata,
+		})
+		for _, warning := range s.metadata.Warnings {
+			warning.SectionID = s.section.ID
+			metadata.Warnings = append(metadata.Warnings, warning)
+		}
+	}
+	if d.opts.WrapWithRoot {
+		closeRoot(builder)
+	}
+	html := builder.string()
+	metadata.Checksum = checksum(html, d.opts.ChecksumIgnoreAttrs)
+	if d.opts.Metrics != nil {
+		d.opts.Metrics.ObserveRender(RenderStats{
+			Duration: time.Since(start),
+			Rows:     metadata.RowCount,
+			Bytes:    len(html),
+			Warnings: len(metadata.Warnings),
+		})
+	}
+	return DocumentResult{HTML: html, Metadata: metadata}
+}
@@ -0,0 +1,66 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocumentRendersSections(t *testing.T) {
+	doc := NewDocument(Options{WrapWithRoot: true})
+	err := AddTable(doc, Section{ID: "people", Title: "People"},
+		[]sampleRow{{Name: "Alice", Age: 30}},
+		Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}},
+		Options{WrapWithRoot: true},
+	)
+	if err != nil {
+		t.Fatalf("add table failed: %v", err)
+	}
+	err = AddTable(doc, Section{ID: "scores"},
+		[]formulaRow{{Name: "Bob"}},
+		Schema[formulaRow]{Columns: []Column[formulaRow]{
+			{Key: "score", Type: ColumnTypeNumber, Formula: func(row formulaRow) any { return nil }},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("add table failed: %v", err)
+	}
+	if err := AddTable(doc, Section{ID: "people"}, []sampleRow{}, Schema[sampleRow]{}, Options{}); err == nil {
+		t.Fatalf("expected duplicate section error")
+	}
+
+	result := doc.Render()
+	if strings.Count(result.HTML, "extable-root") != 1 {
+		t.Fatalf("expected a single shared root")
+	}
+	if !strings.Contains(result.HTML, "<section class=\"extable-section\" id=\"people\"><h2 class=\"extable-section-title\">People</h2><table>") {
+		t.Fatalf("expected titled section anchor: %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "<table>") != 2 {
+		t.Fatalf("expected two tables")
+	}
+	if result.Metadata.RowCount != 2 || len(result.Metadata.Sections) != 2 {
+		t.Fatalf("unexpected metadata: %+v", result.Metadata)
+	}
+	if len(result.Metadata.Warnings) != 1 || result.Metadata.Warnings[0].SectionID != "scores" {
+		t.Fatalf("expected section warning: %+v", result.Metadata.Warnings)
+	}
+}
+
+func TestDocumentSectionIDPrefixes(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "age", Type: ColumnTypeInt, Description: "Age in years"},
+	}}
+	doc := NewDocument(Options{})
+	for _, id := range []string{"north", "south"} {
+		if err := AddTable(doc, Section{ID: id}, []sampleRow{{Age: 1}}, schema, Options{}); err != nil {
+			t.Fatalf("add table failed: %v", err)
+		}
+	}
+	html := doc.Render().HTML
+	for _, id := range []string{"north-desc-age", "south-desc-age"} {
+		if strings.Count(html, `id="`+id+`"`) != 1 || !strings.Contains(html, `aria-describedby="`+id+`"`) {
+			t.Fatalf("expected a unique %s description target: %s", id, html)
+		}
+	}
+}
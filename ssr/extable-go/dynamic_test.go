@@ -0,0 +1,74 @@
+package extable
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+type monthlySales struct {
+	Name  string             `json:"name"`
+	Sales map[string]float64 `json:"sales"`
+}
+
+func monthColumns(data []monthlySales) []Column[monthlySales] {
+	seen := make(map[string]bool)
+	for _, row := range data {
+		for month := range row.Sales {
+			seen[month] = true
+		}
+	}
+	months := make([]string, 0, len(seen))
+	for month := range seen {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	columns := make([]Column[monthlySales], 0, len(months))
+	for _, month := range months {
+		month := month
+		columns = append(columns, Column[monthlySales]{
+			Key: month, Type: ColumnTypeNumber, Virtual: true,
+			Formula: func(row monthlySales) any { return row.Sales[month] },
+		})
+	}
+	return columns
+}
+
+func TestDynamicColumns(t *testing.T) {
+	schema := Schema[monthlySales]{
+		Columns:        []Column[monthlySales]{{Key: "name", Type: ColumnTypeString}},
+		DynamicColumns: monthColumns,
+	}
+	jan := []monthlySales{{Name: "north", Sales: map[string]float64{"2024-01": 10}}}
+	result, err := RenderTableHTML(jan, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if result.Metadata.ColumnCount != 2 || !strings.Contains(result.HTML, `data-col-key="2024-01">10</td>`) {
+		t.Fatalf("expected a generated month column: %+v %s", result.Metadata, result.HTML)
+	}
+	if len(schema.Columns) != 1 {
+		t.Fatalf("schema columns were modified: %d", len(schema.Columns))
+	}
+
+	renderer, err := NewRenderer(schema, Options{})
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	febMar := []monthlySales{{Name: "south", Sales: map[string]float64{"2024-02": 5, "2024-03": 7}}}
+	result, err = renderer.Render(febMar)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if result.Metadata.ColumnCount != 3 || strings.Contains(result.HTML, "2024-01") || !strings.Contains(result.HTML, `data-col-key="2024-03">7</td>`) {
+		t.Fatalf("renderer should regenerate columns per dataset: %+v %s", result.Metadata, result.HTML)
+	}
+
+	head, err := RenderHead(schema, Options{})
+	if err != nil {
+		t.Fatalf("RenderHead failed: %v", err)
+	}
+	if strings.Contains(head.HTML, "2024-") {
+		t.Fatalf("RenderHead has no data to generate columns from: %s", head.HTML)
+	}
+}
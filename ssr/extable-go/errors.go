@@ -0,0 +1,45 @@
+package extable
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNilRowType             = errors.New("ssr: row type is nil")
+	ErrNotStruct              = errors.New("ssr: row type must be a struct, pointer to struct, or string-keyed map")
+	ErrMissingColumnKey       = errors.New("ssr: schema column without key")
+	ErrMissingSectionID       = errors.New("ssr: document section id is required")
+	ErrDuplicateSectionID     = errors.New("ssr: duplicate document section id")
+	ErrMissingTableName       = errors.New("ssr: sql table name is required")
+	ErrUnsupportedDialect     = errors.New("ssr: unsupported sql dialect")
+	ErrNoExportableColumns    = errors.New("ssr: no exportable columns")
+	ErrMissingRowID           = errors.New("ssr: Schema.RowID is required")
+	ErrDuplicateRowID         = errors.New("ssr: duplicate row id")
+	ErrMissingPivotKeys       = errors.New("ssr: pivot row, column, and value keys are required")
+	ErrUnsupportedAggregation = errors.New("ssr: unsupported pivot aggregation")
+	ErrInvalidOptions         = errors.New("ssr: invalid options")
+	ErrBuilderNoColumn        = errors.New("ssr: schema builder modifier called before any column")
+	ErrInvalidSheetName       = errors.New("ssr: invalid xlsx sheet name")
+	ErrTreeCycle              = errors.New("ssr: Schema.Children returned a cycle")
+)
+
+// SchemaError reports a problem with a single column, such as an unknown type
+// or an expression that fails to compile. Err holds the underlying cause, if any.
+type SchemaError struct {
+	ColKey  string
+	Message string
+	Err     error
+}
+
+func (e *SchemaError) Error() string {
+	text := fmt.Sprintf("ssr: column %q %s", e.ColKey, e.Message)
+	if e.Err != nil {
+		text += ": " + e.Err.Error()
+	}
+	return text
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
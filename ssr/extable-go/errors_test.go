@@ -0,0 +1,68 @@
+package extable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	if _, err := RenderTableHTML([]int{1}, Schema[int]{}, Options{}); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("expected ErrNotStruct, got %v", err)
+	}
+	if _, err := RenderTableHTML([]any{}, Schema[any]{}, Options{}); !errors.Is(err, ErrNilRowType) {
+		t.Fatalf("expected ErrNilRowType, got %v", err)
+	}
+
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	_, err := RenderTableHTML([]sampleRow{}, schema, Options{Sort: []SortSpec{{Key: "nope"}}})
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || schemaErr.ColKey != "nope" {
+		t.Fatalf("expected SchemaError for nope, got %v", err)
+	}
+
+	doc := NewDocument(Options{})
+	if err := AddTable(doc, Section{ID: "a"}, []sampleRow{}, schema, Options{}); err != nil {
+		t.Fatalf("add table failed: %v", err)
+	}
+	err = AddTable(doc, Section{ID: "a"}, []sampleRow{}, schema, Options{})
+	if !errors.Is(err, ErrDuplicateSectionID) || err.Error() != `ssr: duplicate document section id "a"` {
+		t.Fatalf("expected wrapped ErrDuplicateSectionID, got %v", err)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	if err := (&Options{}).Validate(); err != nil {
+		t.Fatalf("expected zero options to be valid, got %v", err)
+	}
+	opts := Options{Offset: -1, Direction: "up", RowHeight: -1, Sort: []SortSpec{{Key: "a"}, {Key: "a"}}}
+	err := opts.Validate()
+	var optsErr *OptionsError
+	if !errors.As(err, &optsErr) || len(optsErr.Problems) != 4 {
+		t.Fatalf("expected four aggregated problems, got %v", err)
+	}
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "a", Type: ColumnTypeString}}}
+	if _, err := RenderTableHTML([]sampleRow{}, schema, opts); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("expected render to reject invalid options, got %v", err)
+	}
+}
+
+func TestValidateOptionsCombinations(t *testing.T) {
+	schema := Schema[sampleRow]{
+		Columns:  []Column[sampleRow]{{Key: "a", Type: ColumnTypeString}},
+		Children: func(sampleRow) []sampleRow { return nil },
+	}
+	err := schema.ValidateOptions(Options{GroupBy: "a", RowHeight: 20, Responsive: &Responsive{Mode: ResponsiveStack}})
+	var optsErr *OptionsError
+	if !errors.As(err, &optsErr) || len(optsErr.Problems) != 2 || !strings.Contains(err.Error(), "Schema.Children") {
+		t.Fatalf("expected two combination problems, got %v", err)
+	}
+	schema.Children = nil
+	result, err := RenderTableHTML([]sampleRow{}, schema, Options{DefaultClass: []string{"x"}})
+	if err != nil {
+		t.Fatalf("DefaultClass without WrapWithRoot should still render, got %v", err)
+	}
+	if len(result.Metadata.Warnings) != 1 || !strings.Contains(result.Metadata.Warnings[0].Message, "WrapWithRoot") {
+		t.Fatalf("expected an ignored-option warning, got %+v", result.Metadata.Warnings)
+	}
+}
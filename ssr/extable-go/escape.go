@@ -1,14 +1,67 @@
 package extable
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var htmlReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\"", "&quot;",
+	"'", "&#39;",
+)
 
 func escapeHTML(text string) string {
-	replacer := strings.NewReplacer(
-		"&", "&amp;",
-		"<", "&lt;",
-		">", "&gt;",
-		"\"", "&quot;",
-		"'", "&#39;",
-	)
-	return replacer.Replace(text)
+	return htmlReplacer.Replace(text)
+}
+
+// escapeAttr escapes a double-quoted attribute value. On top of escapeHTML it
+// replaces control characters other than tab, newline and carriage return, and
+// invalid UTF-8, with U+FFFD, since browsers treat them as parse errors.
+func escapeAttr(value string) string {
+	clean := true
+	for _, c := range value {
+		if c == utf8.RuneError || isControlRune(c) {
+			clean = false
+			break
+		}
+	}
+	if clean {
+		return escapeHTML(value)
+	}
+	var sb strings.Builder
+	for _, c := range value {
+		if isControlRune(c) {
+			c = utf8.RuneError
+		}
+		sb.WriteRune(c)
+	}
+	return escapeHTML(sb.String())
+}
+
+func isControlRune(c rune) bool {
+	if c == '\t' || c == '\n' || c == '\r' {
+		return false
+	}
+	return c < 0x20 || (c >= 0x7f && c <= 0x9f)
+}
+
+// validAttrName reports whether name can be written as an attribute name
+// without ending the tag or starting another attribute.
+func validAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch c {
+		case ' ', '"', '\'', '>', '/', '=', '<', '`', '\t', '\n', '\f', '\r', utf8.RuneError:
+			return false
+		}
+		if isControlRune(c) {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,238 @@
+package extable
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SQLDialect string
+
+const (
+	SQLDialectPostgres SQLDialect = "postgres"
+	SQLDialectMySQL    SQLDialect = "mysql"
+	SQLDialectSQLite   SQLDialect = "sqlite"
+)
+
+type SQLOptions struct {
+	Table   string
+	Dialect SQLDialect
+	// ConflictKeys switches the output to upsert statements keyed by these columns.
+	ConflictKeys []string
+}
+
+func ExportSQL[T any](w io.Writer, data []T, schema Schema[T], opts SQLOptions) error {
+	if opts.Table == "" {
+		return ErrMissingTableName
+	}
+	dialect := opts.Dialect
+	if dialect == "" {
+		dialect = SQLDialectPostgres
+	}
+	if dialect != SQLDialectPostgres && dialect != SQLDialectMySQL && dialect != SQLDialectSQLite {
+		return fmt.Errorf("%w %q", ErrUnsupportedDialect, dialect)
+	}
+	getter, columns, err := exportColumns(schema.withDynamicColumns(data))
+	if err != nil {
+		return err
+	}
+	exported := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		exported[col.Key] = true
+	}
+	for _, key := range opts.ConflictKeys {
+		if !exported[key] {
+			return &SchemaError{ColKey: key, Message: "is a sql conflict key but not an exported column"}
+		}
+	}
+
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, quoteSQLIdentifier(col.Key, dialect))
+	}
+	prefix := "INSERT INTO " + quoteSQLTable(opts.Table, dialect) + " (" + strings.Join(names, ", ") + ") VALUES ("
+	suffix := sqlConflictClause(columns, opts.ConflictKeys, dialect) + ";\n"
+
+	values := make([]string, len(columns))
+	for _, row := range data {
+		for i, col := range columns {
+			value, _ := getter.valueForKey(row, col.Key)
+			values[i] = sqlLiteral(value, col, dialect)
+		}
+		if _, err := io.WriteString(w, prefix+strings.Join(values, ", ")+")"+suffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportColumns[T any](schema Schema[T]) (*fieldGetter, []Column[T], error) {
+	getter, err := newFieldGetter[T]()
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make([]Column[T], 0, len(schema.Columns))
+	for _, col := range schema.Columns {
+		if !isExportableColumn(col) || !schema.columnVisible(col) || !getter.hasKey(col.Key) {
+			continue
+		}
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return nil, nil, ErrNoExportableColumns
+	}
+	return getter, columns, nil
+}
+
+func isExportableColumn[T any](col Column[T]) bool {
+	if col.derived() {
+		return false
+	}
+	return col.Type != ColumnTypeButton && col.Type != ColumnTypeLink
+}
+
+func sqlConflictClause[T any](columns []Column[T], conflictKeys []string, dialect SQLDialect) string {
+	if len(conflictKeys) == 0 {
+		return ""
+	}
+	isKey := make(map[string]bool, len(conflictKeys))
+	for _, key := range conflictKeys {
+		isKey[key] = true
+	}
+	updates := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if isKey[col.Key] {
+			continue
+		}
+		name := quoteSQLIdentifier(col.Key, dialect)
+		if dialect == SQLDialectMySQL {
+			updates = append(updates, name+" = VALUES("+name+")")
+		} else {
+			updates = append(updates, name+" = EXCLUDED."+name)
+		}
+	}
+	if dialect == SQLDialectMySQL {
+		if len(updates) == 0 {
+			first := quoteSQLIdentifier(conflictKeys[0], dialect)
+			updates = append(updates, first+" = "+first)
+		}
+		return " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", ")
+	}
+	keys := make([]string, 0, len(conflictKeys))
+	for _, key := range conflictKeys {
+		keys = append(keys, quoteSQLIdentifier(key, dialect))
+	}
+	if len(updates) == 0 {
+		return " ON CONFLICT (" + strings.Join(keys, ", ") + ") DO NOTHING"
+	}
+	return " ON CONFLICT (" + strings.Join(keys, ", ") + ") DO UPDATE SET " + strings.Join(updates, ", ")
+}
+
+func quoteSQLTable(table string, dialect SQLDialect) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = quoteSQLIdentifier(part, dialect)
+	}
+	return strings.Join(parts, ".")
+}
+
+func quoteSQLIdentifier(name string, dialect SQLDialect) string {
+	if dialect == SQLDialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+}
+
+func quoteSQLString(text string, dialect SQLDialect) string {
+	text = strings.ReplaceAll(text, "\x00", "")
+	if dialect == SQLDialectMySQL {
+		text = strings.ReplaceAll(text, "\\", "\\\\")
+	}
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
+
+func sqlLiteral[T any](value any, col Column[T], dialect SQLDialect) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if dialect == SQLDialectSQLite {
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case float32:
+		return sqlFloat(float64(v))
+	case float64:
+		return sqlFloat(v)
+	case time.Time:
+		return quoteSQLString(v.Format(sqlTimeLayout(col.Type)), dialect)
+	case *time.Time:
+		if v == nil {
+			return "NULL"
+		}
+		return quoteSQLString(v.Format(sqlTimeLayout(col.Type)), dialect)
+	case []string:
+		sep := ", "
+		if col.Tags != nil && col.Tags.Separator != "" {
+			sep = col.Tags.Separator
+		}
+		return quoteSQLString(strings.Join(v, sep), dialect)
+	case string:
+		return quoteSQLString(v, dialect)
+	default:
+		if text, ok := formatBigNumber(value, -1); ok {
+			if text == "" {
+				return "NULL"
+			}
+			// DecimalStringer text comes from user code; only plain numbers go in unquoted.
+			if sqlNumberPattern.MatchString(text) {
+				return text
+			}
+			return quoteSQLString(text, dialect)
+		}
+		return quoteSQLString(stringifyValue(value), dialect)
+	}
+}
+
+var sqlNumberPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// sqlFloat writes NaN and infinities, which have no SQL literal, as NULL.
+func sqlFloat(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "NULL"
+	}
+	return formatFloat(v, -1)
+}
+
+func sqlTimeLayout(colType ColumnType) string {
+	switch colType {
+	case ColumnTypeDate:
+		return "2006-01-02"
+	case ColumnTypeTime:
+		return "15:04:05"
+	default:
+		return "2006-01-02 15:04:05"
+	}
+}
@@ -0,0 +1,72 @@
+package extable
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+type exportRow struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+func TestExportSQLInsert(t *testing.T) {
+	var sb strings.Builder
+	err := ExportSQL(&sb,
+		[]exportRow{{ID: 1, Name: "O'Brien", Active: true}},
+		Schema[exportRow]{Columns: []Column[exportRow]{
+			{Key: "id", Type: ColumnTypeInt},
+			{Key: "name", Type: ColumnTypeString},
+			{Key: "active", Type: ColumnTypeBoolean},
+			{Key: "open", Type: ColumnTypeButton},
+		}},
+		SQLOptions{Table: "public.users"},
+	)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	expected := "INSERT INTO \"public\".\"users\" (\"id\", \"name\", \"active\") VALUES (1, 'O''Brien', TRUE);\n"
+	if sb.String() != expected {
+		t.Fatalf("unexpected sql: %s", sb.String())
+	}
+}
+
+func TestExportSQLUpsertMySQL(t *testing.T) {
+	var sb strings.Builder
+	err := ExportSQL(&sb,
+		[]exportRow{{ID: 1, Name: `a\b`}},
+		Schema[exportRow]{Columns: []Column[exportRow]{
+			{Key: "id", Type: ColumnTypeInt},
+			{Key: "name", Type: ColumnTypeString},
+		}},
+		SQLOptions{Table: "users", Dialect: SQLDialectMySQL, ConflictKeys: []string{"id"}},
+	)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	expected := "INSERT INTO `users` (`id`, `name`) VALUES (1, 'a\\\\b') ON DUPLICATE KEY UPDATE `name` = VALUES(`name`);\n"
+	if sb.String() != expected {
+		t.Fatalf("unexpected sql: %s", sb.String())
+	}
+}
+
+func TestExportSQLRequiresTable(t *testing.T) {
+	err := ExportSQL(&strings.Builder{}, []exportRow{}, Schema[exportRow]{}, SQLOptions{})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestSQLLiteralNonFiniteFloats(t *testing.T) {
+	col := Column[exportRow]{Key: "score", Type: ColumnTypeNumber}
+	for _, value := range []any{math.NaN(), math.Inf(1), float32(math.Inf(-1))} {
+		if got := sqlLiteral(value, col, SQLDialectPostgres); got != "NULL" {
+			t.Fatalf("sqlLiteral(%v) = %q, want NULL", value, got)
+		}
+	}
+	if got := sqlLiteral(1.5, col, SQLDialectPostgres); got != "1.5" {
+		t.Fatalf("finite floats should stay numeric, got %q", got)
+	}
+}
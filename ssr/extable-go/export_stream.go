@@ -0,0 +1,261 @@
+package extable
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StreamOptions configures ExportCSV and ExportXLSX.
+type StreamOptions struct {
+	// FlushEvery flushes buffered output after this many rows; 1000 when zero.
+	// When w has a Flush method (http.ResponseWriter, bufio.Writer) it is
+	// flushed too, so clients start receiving data before the export ends.
+	FlushEvery int
+	// Sheet names the XLSX worksheet; "Sheet1" when empty.
+	Sheet string
+}
+
+func (o StreamOptions) flushEvery() int {
+	if o.FlushEvery > 0 {
+		return o.FlushEvery
+	}
+	return 1000
+}
+
+// ExportCSV writes a header row and one record per row of data. Values are
+// written raw (not through Column.Format) so the file reads back with
+// LoadCSV. It stops with ctx.Err() when ctx is done.
+func ExportCSV[T any](ctx context.Context, w io.Writer, data []T, schema Schema[T], opts StreamOptions) error {
+	getter, columns, err := exportColumns(schema.withDynamicColumns(data))
+	if err != nil {
+		return err
+	}
+	buffered := bufio.NewWriter(w)
+	writer := csv.NewWriter(buffered)
+	flush := func() error {
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		if err := buffered.Flush(); err != nil {
+			return err
+		}
+		return flushWriter(w)
+	}
+
+	record := make([]string, len(columns))
+	for i, col := range columns {
+		record[i] = exportHeader(col)
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	every := opts.flushEvery()
+	for n, row := range data {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for i, col := range columns {
+			value, _ := getter.valueForKey(row, col.Key)
+			record[i] = exportText(value, col)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		if (n+1)%every == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// ExportXLSX writes a single-sheet workbook. Numbers and booleans become typed
+// cells; everything else, dates included, is written as text. Rows are
+// streamed into the archive, so memory use does not grow with len(data).
+// It stops with ctx.Err() when ctx is done, leaving w with a truncated file.
+func ExportXLSX[T any](ctx context.Context, w io.Writer, data []T, schema Schema[T], opts StreamOptions) error {
+	sheet := opts.Sheet
+	if sheet == "" {
+		sheet = "Sheet1"
+	}
+	if len([]rune(sheet)) > 31 || strings.ContainsAny(sheet, `[]:*?/\`) {
+		return fmt.Errorf("%w %q", ErrInvalidSheetName, sheet)
+	}
+	getter, columns, err := exportColumns(schema.withDynamicColumns(data))
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts(sheet) {
+		entry, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, part.body); err != nil {
+			return err
+		}
+	}
+	entry, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	buffered := bufio.NewWriter(entry)
+	buffered.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	buffered.WriteString("<row>")
+	for _, col := range columns {
+		writeXLSXCell(buffered, exportHeader(col), col)
+	}
+	buffered.WriteString("</row>")
+	every := opts.flushEvery()
+	for n, row := range data {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		buffered.WriteString("<row>")
+		for _, col := range columns {
+			value, _ := getter.valueForKey(row, col.Key)
+			writeXLSXCell(buffered, value, col)
+		}
+		buffered.WriteString("</row>")
+		if (n+1)%every == 0 {
+			if err := buffered.Flush(); err != nil {
+				return err
+			}
+			if err := archive.Flush(); err != nil {
+				return err
+			}
+			if err := flushWriter(w); err != nil {
+				return err
+			}
+		}
+	}
+	buffered.WriteString("</sheetData></worksheet>")
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return flushWriter(w)
+}
+
+func flushWriter(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
+
+func exportHeader[T any](col Column[T]) string {
+	if col.Header != "" {
+		return col.Header
+	}
+	return col.Key
+}
+
+func exportText[T any](value any, col Column[T]) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(exportTimeLayout(col.Type))
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(exportTimeLayout(col.Type))
+	case []string:
+		sep := ", "
+		if col.Tags != nil && col.Tags.Separator != "" {
+			sep = col.Tags.Separator
+		}
+		return strings.Join(v, sep)
+	case float32:
+		return formatFloat(float64(v), -1)
+	case float64:
+		return formatFloat(v, -1)
+	default:
+		if text, ok := formatBigNumber(value, -1); ok {
+			return text
+		}
+		return stringifyValue(value)
+	}
+}
+
+func exportTimeLayout(colType ColumnType) string {
+	if colType == ColumnTypeDateTime {
+		return time.RFC3339
+	}
+	return sqlTimeLayout(colType)
+}
+
+func writeXLSXCell[T any](w *bufio.Writer, value any, col Column[T]) {
+	switch v := value.(type) {
+	case nil:
+		w.WriteString("<c/>")
+		return
+	case bool:
+		if v {
+			w.WriteString(`<c t="b"><v>1</v></c>`)
+		} else {
+			w.WriteString(`<c t="b"><v>0</v></c>`)
+		}
+		return
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		w.WriteString(`<c t="n"><v>` + stringifyValue(v) + `</v></c>`)
+		return
+	case float32, float64:
+		if n, _ := numericValue(v); !math.IsNaN(n) && !math.IsInf(n, 0) {
+			w.WriteString(`<c t="n"><v>` + strconv.FormatFloat(n, 'g', -1, 64) + `</v></c>`)
+			return
+		}
+	}
+	w.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	xml.EscapeText(w, []byte(exportText(value, col)))
+	w.WriteString("</t></is></c>")
+}
+
+type xlsxPart struct {
+	name string
+	body string
+}
+
+func xlsxParts(sheet string) []xlsxPart {
+	var name strings.Builder
+	xml.EscapeText(&name, []byte(sheet))
+	return []xlsxPart{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + name.String() + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+	}
+}
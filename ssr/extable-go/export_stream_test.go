@@ -0,0 +1,86 @@
+package extable
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushRecorder) Flush() { f.flushes++ }
+
+var exportStreamSchema = Schema[exportRow]{Columns: []Column[exportRow]{
+	{Key: "id", Type: ColumnTypeInt, Header: "ID"},
+	{Key: "name", Type: ColumnTypeString},
+	{Key: "active", Type: ColumnTypeBoolean},
+	{Key: "open", Type: ColumnTypeButton},
+}}
+
+func TestExportCSV(t *testing.T) {
+	rows := []exportRow{{ID: 1, Name: "a,b", Active: true}, {ID: 2, Name: "c"}, {ID: 3, Name: "d"}}
+	var out flushRecorder
+	if err := ExportCSV(context.Background(), &out, rows, exportStreamSchema, StreamOptions{FlushEvery: 2}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	expected := "ID,name,active\n1,\"a,b\",true\n2,c,false\n3,d,false\n"
+	if out.String() != expected {
+		t.Fatalf("unexpected csv: %q", out.String())
+	}
+	if out.flushes != 2 {
+		t.Fatalf("expected a periodic and a final flush, got %d", out.flushes)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ExportCSV(ctx, io.Discard, rows, exportStreamSchema, StreamOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
+
+func TestExportXLSX(t *testing.T) {
+	rows := []exportRow{{ID: 1, Name: "<x> & y", Active: true}}
+	var out bytes.Buffer
+	if err := ExportXLSX(context.Background(), &out, rows, exportStreamSchema, StreamOptions{Sheet: "Users"}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(body)
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="Users"`) {
+		t.Fatalf("missing sheet name: %s", files["xl/workbook.xml"])
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c t="inlineStr"><is><t xml:space="preserve">ID</t></is></c>`,
+		`<c t="n"><v>1</v></c>`,
+		`&lt;x&gt; &amp; y`,
+		`<c t="b"><v>1</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Fatalf("sheet missing %s: %s", want, sheet)
+		}
+	}
+
+	if err := ExportXLSX(context.Background(), io.Discard, rows, exportStreamSchema, StreamOptions{Sheet: "a/b"}); !errors.Is(err, ErrInvalidSheetName) {
+		t.Fatalf("expected invalid sheet name, got %v", err)
+	}
+}
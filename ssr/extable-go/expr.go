@@ -0,0 +1,107 @@
+package extable
+
+import (
+	"reflect"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// ClassRule adds Class to a cell when the expression When evaluates to true.
+type ClassRule struct {
+	When  string `json:"when"`
+	Class string `json:"class"`
+}
+
+// programs caches compiled Column.Expr and ClassRule.When sources. Expressions
+// see the row's fields by column key and cannot call Go functions beyond the
+// expr builtins.
+var programs = newLRUCache[*vm.Program](maxCompiledSources)
+
+func compileExpr(source string, predicate bool) (*vm.Program, error) {
+	cacheKey := source
+	if predicate {
+		cacheKey = "?" + source
+	}
+	if cached, ok := programs.get(cacheKey); ok {
+		return cached, nil
+	}
+	options := []expr.Option{expr.AllowUndefinedVariables()}
+	if predicate {
+		options = append(options, expr.AsBool())
+	}
+	program, err := expr.Compile(source, options...)
+	if err != nil {
+		return nil, err
+	}
+	programs.add(cacheKey, program)
+	return program, nil
+}
+
+func checkExpressions[T any](columns []Column[T]) error {
+	for _, col := range columns {
+		if col.Expr != "" {
+			if _, err := compileExpr(col.Expr, false); err != nil {
+				return &SchemaError{ColKey: col.Key, Message: "expr", Err: err}
+			}
+		}
+		for _, rule := range col.ClassRules {
+			if _, err := compileExpr(rule.When, true); err != nil {
+				return &SchemaError{ColKey: col.Key, Message: "class rule", Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+func evalExpr(source string, predicate bool, env map[string]any) (any, bool) {
+	program, err := compileExpr(source, predicate)
+	if err != nil {
+		return nil, false
+	}
+	value, err := expr.Run(program, env)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// rowEnv exposes every field of row by its column key.
+func (g *fieldGetter) rowEnv(row any) map[string]any {
+	if g.mapRows {
+		env := make(map[string]any)
+		value := reflect.ValueOf(row)
+		if value.Kind() == reflect.Map && !value.IsNil() {
+			iter := value.MapRange()
+			for iter.Next() {
+				env[iter.Key().String()] = normalizeValue(iter.Value().Interface())
+			}
+		}
+		return env
+	}
+	env := make(map[string]any, len(g.keyNames))
+	for key := range g.keyNames {
+		env[key], _ = g.valueForKey(row, key)
+	}
+	return env
+}
+
+// cellClasses evaluates the column's class rules; rules that fail to run are skipped.
+func (r *tableRenderer[T]) cellClasses(rowIndex int, col Column[T], row T) []string {
+	if len(col.ClassRules) == 0 {
+		return nil
+	}
+	env := r.getter.rowEnv(row)
+	classes := make([]string, 0)
+	for _, rule := range col.ClassRules {
+		matched, ok := evalExpr(rule.When, true, env)
+		if !ok {
+			r.warn(rowIndex, col.Key, "class rule failed")
+			continue
+		}
+		if matched == true {
+			classes = append(classes, rule.Class)
+		}
+	}
+	return classes
+}
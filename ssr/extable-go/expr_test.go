@@ -0,0 +1,41 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+type invoiceRow struct {
+	Qty   int     `json:"qty"`
+	Price float64 `json:"price"`
+}
+
+func TestExprColumnsFromJSON(t *testing.T) {
+	schema, err := ParseSchemaJSON[invoiceRow]([]byte(`{"columns":[
+		{"key":"qty","type":"int","classRules":[{"when":"qty == 0","class":"is-empty"}]},
+		{"key":"total","type":"number","expr":"qty * price","classRules":[{"when":"qty * price > 100","class":"is-large"}]}
+	]}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	result, err := RenderTableHTML([]invoiceRow{{Qty: 3, Price: 50}, {Qty: 0, Price: 9}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `align-right is-large extable-readonly" data-col-key="total">150</td>`) {
+		t.Fatalf("expected computed and classed total, got %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "is-large") != 1 || strings.Count(result.HTML, "is-empty") != 1 {
+		t.Fatalf("expected class rules to match one row each, got %s", result.HTML)
+	}
+	if len(result.Metadata.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %+v", result.Metadata.Warnings)
+	}
+}
+
+func TestExprCompileError(t *testing.T) {
+	_, err := ParseSchemaJSON[invoiceRow]([]byte(`{"columns":[{"key":"total","type":"number","expr":"qty *"}]}`))
+	if err == nil || !strings.Contains(err.Error(), `column "total" expr`) {
+		t.Fatalf("expected compile error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package extabletest
+
+import (
+	"testing"
+
+	extable "github.com/shibukawayoshiki/extable/ssr/extable-go"
+)
+
+// Benchmark runs a render benchmark for one schema and dataset, reporting
+// allocations, output bytes per op (as MB/s) and rows/op. It benchmarks
+// RenderTableHTML and a NewRenderer-compiled renderer as sub-benchmarks, so
+// results from `go test -bench` can be compared with benchstat:
+//
+//	func BenchmarkOrders(b *testing.B) {
+//		extabletest.Benchmark(b, orders, orderSchema, extable.Options{})
+//	}
+func Benchmark[T any](b *testing.B, data []T, schema extable.Schema[T], opts extable.Options) {
+	b.Helper()
+	b.Run("RenderTableHTML", func(b *testing.B) {
+		runBenchmark(b, func() (extable.Result, error) {
+			return extable.RenderTableHTML(data, schema, opts)
+		})
+	})
+	b.Run("Renderer", func(b *testing.B) {
+		renderer, err := extable.NewRenderer(schema, opts)
+		if err != nil {
+			b.Fatalf("extabletest: compile failed: %v", err)
+		}
+		runBenchmark(b, func() (extable.Result, error) {
+			return renderer.Render(data)
+		})
+	})
+}
+
+func runBenchmark(b *testing.B, render func() (extable.Result, error)) {
+	result, err := render()
+	if err != nil {
+		b.Fatalf("extabletest: render failed: %v", err)
+	}
+	b.SetBytes(int64(len(result.HTML)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := render(); err != nil {
+			b.Fatalf("extabletest: render failed: %v", err)
+		}
+	}
+	b.ReportMetric(float64(result.Metadata.RowCount), "rows/op")
+}
+
+// Budget caps what a single render may cost; zero fields are not checked.
+type Budget struct {
+	Allocations uint64
+	Bytes       uint64
+}
+
+// AssertBudget renders once to warm up, then again with the result's
+// Metadata.Stats, and fails if the second render exceeds budget. render must
+// set Options.CollectStats. Run budget tests without t.Parallel, since the
+// runtime counters are process-wide.
+func AssertBudget(t testing.TB, budget Budget, render func() (extable.Result, error)) {
+	t.Helper()
+	if _, err := render(); err != nil {
+		t.Fatalf("extabletest: render failed: %v", err)
+	}
+	result, err := render()
+	if err != nil {
+		t.Fatalf("extabletest: render failed: %v", err)
+	}
+	stats := result.Metadata.Stats
+	if stats == nil {
+		t.Fatalf("extabletest: Metadata.Stats is empty; set Options.CollectStats")
+	}
+	if budget.Allocations > 0 && stats.Allocations > budget.Allocations {
+		t.Errorf("extabletest: render made %d allocations, budget is %d", stats.Allocations, budget.Allocations)
+	}
+	if budget.Bytes > 0 && stats.Bytes > budget.Bytes {
+		t.Errorf("extabletest: render allocated %d bytes, budget is %d", stats.Bytes, budget.Bytes)
+	}
+}
@@ -0,0 +1,42 @@
+package extabletest
+
+import (
+	"strconv"
+	"testing"
+
+	extable "github.com/shibukawayoshiki/extable/ssr/extable-go"
+)
+
+func benchPeople(n int) []person {
+	people := make([]person, n)
+	for i := range people {
+		people[i] = person{Name: "person " + strconv.Itoa(i), Age: i % 90}
+	}
+	return people
+}
+
+var benchSchema = extable.Schema[person]{Columns: []extable.Column[person]{
+	{Key: "name", Type: extable.ColumnTypeString, Header: "Name"},
+	{Key: "age", Type: extable.ColumnTypeInt, Header: "Age"},
+}}
+
+func BenchmarkRender1000(b *testing.B) {
+	Benchmark(b, benchPeople(1000), benchSchema, extable.Options{})
+}
+
+func TestAssertBudget(t *testing.T) {
+	people := benchPeople(100)
+	render := func() (extable.Result, error) {
+		return extable.RenderTableHTML(people, benchSchema, extable.Options{CollectStats: true})
+	}
+	AssertBudget(t, Budget{Allocations: 100000, Bytes: 64 << 20}, render)
+
+	result, err := render()
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	stats := result.Metadata.Stats
+	if stats == nil || stats.Allocations == 0 || stats.Bytes == 0 || stats.Duration <= 0 {
+		t.Fatalf("expected populated stats, got %+v", stats)
+	}
+}
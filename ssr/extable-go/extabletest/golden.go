@@ -0,0 +1,248 @@
+// Package extabletest provides helpers for snapshot-testing extable output.
+package extabletest
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	extable "github.com/shibukawayoshiki/extable/ssr/extable-go"
+)
+
+var update = flag.Bool("extable.update", false, "rewrite extable golden files with the current output")
+
+// AssertGolden compares the normalized HTML of result with the golden file at
+// path. Run tests with -extable.update (or EXTABLE_UPDATE_GOLDEN=1) to rewrite
+// the file instead.
+func AssertGolden(t testing.TB, result extable.Result, path string) {
+	t.Helper()
+	actual := NormalizeHTML(result.HTML)
+	if shouldUpdate() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("extabletest: create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Fatalf("extabletest: write golden file: %v", err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("extabletest: golden file %s does not exist; run with -extable.update to create it", path)
+	}
+	if err != nil {
+		t.Fatalf("extabletest: read golden file: %v", err)
+	}
+	if diff := firstDifference(string(expected), actual); diff != "" {
+		t.Errorf("extabletest: output does not match %s\n%s", path, diff)
+	}
+}
+
+func shouldUpdate() bool {
+	return *update || os.Getenv("EXTABLE_UPDATE_GOLDEN") == "1"
+}
+
+// NormalizeHTML rewrites html into one tag or text node per line, with
+// attributes sorted by name and insignificant whitespace collapsed, so that
+// semantically equal markup compares equal.
+func NormalizeHTML(html string) string {
+	lines := make([]string, 0)
+	for _, token := range tokenize(html) {
+		if strings.HasPrefix(token, "<") {
+			lines = append(lines, normalizeTag(token))
+			continue
+		}
+		text := strings.Join(strings.Fields(token), " ")
+		if text != "" {
+			lines = append(lines, text)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func tokenize(html string) []string {
+	tokens := make([]string, 0)
+	for html != "" {
+		if html[0] != '<' {
+			end := strings.IndexByte(html, '<')
+			if end < 0 {
+				end = len(html)
+			}
+			tokens = append(tokens, html[:end])
+			html = html[end:]
+			continue
+		}
+		if strings.HasPrefix(html, "<!--") {
+			end := strings.Index(html, "-->")
+			if end < 0 {
+				end = len(html) - 3
+			}
+			tokens = append(tokens, html[:end+3])
+			html = html[end+3:]
+			continue
+		}
+		end := tagEnd(html)
+		tokens = append(tokens, html[:end])
+		html = html[end:]
+	}
+	return tokens
+}
+
+func tagEnd(html string) int {
+	var quote byte
+	for i := 1; i < len(html); i += 1 {
+		c := html[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return len(html)
+}
+
+type attribute struct {
+	name  string
+	value string
+	bare  bool
+}
+
+func normalizeTag(tag string) string {
+	if strings.HasPrefix(tag, "</") || strings.HasPrefix(tag, "<!") {
+		return strings.Join(strings.Fields(tag), " ")
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(tag, "<"), ">")
+	selfClosing := strings.HasSuffix(body, "/")
+	body = strings.TrimSuffix(body, "/")
+	name, rest := splitName(body)
+	attrs := parseAttributes(rest)
+	sort.SliceStable(attrs, func(i, j int) bool { return attrs[i].name < attrs[j].name })
+
+	var sb strings.Builder
+	sb.WriteString("<")
+	sb.WriteString(strings.ToLower(name))
+	for _, attr := range attrs {
+		sb.WriteString(" ")
+		sb.WriteString(attr.name)
+		if attr.bare {
+			continue
+		}
+		sb.WriteString("=\"")
+		sb.WriteString(strings.ReplaceAll(attr.value, "\"", "&quot;"))
+		sb.WriteString("\"")
+	}
+	if selfClosing {
+		sb.WriteString(" /")
+	}
+	sb.WriteString(">")
+	return sb.String()
+}
+
+func splitName(body string) (string, string) {
+	end := strings.IndexFunc(body, isSpace)
+	if end < 0 {
+		return body, ""
+	}
+	return body[:end], body[end:]
+}
+
+func parseAttributes(text string) []attribute {
+	attrs := make([]attribute, 0)
+	for {
+		text = strings.TrimLeftFunc(text, isSpace)
+		if text == "" {
+			return attrs
+		}
+		end := strings.IndexFunc(text, func(r rune) bool { return r == '=' || isSpace(r) })
+		if end < 0 {
+			return append(attrs, attribute{name: strings.ToLower(text), bare: true})
+		}
+		name := strings.ToLower(text[:end])
+		text = strings.TrimLeftFunc(text[end:], isSpace)
+		if !strings.HasPrefix(text, "=") {
+			attrs = append(attrs, attribute{name: name, bare: true})
+			continue
+		}
+		text = strings.TrimLeftFunc(text[1:], isSpace)
+		var value string
+		if text != "" && (text[0] == '"' || text[0] == '\'') {
+			closing := strings.IndexByte(text[1:], text[0])
+			if closing < 0 {
+				closing = len(text) - 1
+			}
+			value = text[1 : closing+1]
+			text = text[min(closing+2, len(text)):]
+		} else {
+			valueEnd := strings.IndexFunc(text, isSpace)
+			if valueEnd < 0 {
+				valueEnd = len(text)
+			}
+			value = text[:valueEnd]
+			text = text[valueEnd:]
+		}
+		attrs = append(attrs, attribute{name: name, value: value})
+	}
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f'
+}
+
+func firstDifference(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i += 1 {
+		var want, got string
+		if i < len(expectedLines) {
+			want = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			got = actualLines[i]
+		}
+		if want != got {
+			return "first difference at line " + strconv.Itoa(i+1) + ":\n  want: " + want + "\n  got:  " + got
+		}
+	}
+	return ""
+}
+
+// AssertDeterministic calls render several times and fails unless every call
+// produces byte-identical HTML and the same checksum. Output that varies
+// between identical renders defeats caching and ETags.
+func AssertDeterministic(t testing.TB, render func() (extable.Result, error)) {
+	t.Helper()
+	first, err := render()
+	if err != nil {
+		t.Fatalf("extabletest: render failed: %v", err)
+	}
+	for i := 1; i < 3; i += 1 {
+		next, err := render()
+		if err != nil {
+			t.Fatalf("extabletest: render failed: %v", err)
+		}
+		if next.HTML != first.HTML || next.Metadata.Checksum != first.Metadata.Checksum {
+			diff := firstDifference(NormalizeHTML(first.HTML), NormalizeHTML(next.HTML))
+			if diff == "" {
+				diff = "normalized markup is equal; attribute order or whitespace differs"
+			}
+			t.Fatalf("extabletest: render %d differs from the first\n%s", i+1, diff)
+		}
+	}
+}
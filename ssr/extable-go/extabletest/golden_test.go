@@ -0,0 +1,56 @@
+package extabletest
+
+import (
+	"testing"
+
+	extable "github.com/shibukawayoshiki/extable/ssr/extable-go"
+)
+
+type person struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestNormalizeHTML(t *testing.T) {
+	got := NormalizeHTML(`<td  data-col-key="a" class='x'>  hello
+	world </td><br/>`)
+	want := "<td class=\"x\" data-col-key=\"a\">\nhello world\n</td>\n<br />\n"
+	if got != want {
+		t.Fatalf("unexpected normalization:\n%s", got)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	result, err := extable.RenderTableHTML(
+		[]person{{Name: "Alice", Age: 30}},
+		extable.Schema[person]{Columns: []extable.Column[person]{
+			{Key: "name", Type: extable.ColumnTypeString, Header: "Name"},
+			{Key: "age", Type: extable.ColumnTypeInt, Header: "Age"},
+		}},
+		extable.Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	AssertGolden(t, result, "testdata/basic.html")
+}
+
+func TestAssertDeterministic(t *testing.T) {
+	AssertDeterministic(t, func() (extable.Result, error) {
+		return extable.RenderTableHTML(
+			[]person{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 25}},
+			extable.Schema[person]{Columns: []extable.Column[person]{
+				{Key: "name", Type: extable.ColumnTypeString, Priority: 2},
+				{Key: "age", Type: extable.ColumnTypeInt, Heatmap: &extable.HeatmapSpec{}},
+			}},
+			extable.Options{
+				WrapWithRoot:        true,
+				DefaultStyle:        map[string]string{"height": "200px", "width": "100%", "color": "red"},
+				EmitCellCoordinates: true,
+				EmitRowHash:         true,
+				Responsive:          &extable.Responsive{Mode: extable.ResponsiveStack, Breakpoint: 600},
+				Sort:                []extable.SortSpec{{Key: "age"}},
+			},
+		)
+	})
+}
@@ -0,0 +1,178 @@
+package extable
+
+import (
+	"mime/multipart"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// FileSpec constrains file columns. Accept entries are extensions (".pdf"),
+// MIME types ("image/png") or wildcards ("image/*"), as in the HTML accept
+// attribute; MaxSize is in bytes and 0 means no limit.
+type FileSpec struct {
+	Accept   []string `json:"accept,omitempty"`
+	MaxSize  int64    `json:"maxSize,omitempty"`
+	Multiple bool     `json:"multiple,omitempty"`
+}
+
+// FileValue is a stored file shown in a file column. Without Href the name is
+// shown as text.
+type FileValue struct {
+	Name string
+	Href string
+	Size int64
+}
+
+func fileValues(value any) ([]FileValue, bool) {
+	switch v := value.(type) {
+	case FileValue:
+		return []FileValue{v}, true
+	case *FileValue:
+		if v == nil {
+			return nil, false
+		}
+		return []FileValue{*v}, true
+	case []FileValue:
+		return v, true
+	case string:
+		if v == "" {
+			return nil, true
+		}
+		return []FileValue{{Name: v}}, true
+	}
+	return nil, false
+}
+
+func formatFiles(value any) (string, bool) {
+	files, ok := fileValues(value)
+	if !ok {
+		return "", false
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Name
+	}
+	return strings.Join(names, ", "), true
+}
+
+func (r *tableRenderer[T]) renderFiles(builder *htmlBuilder, rowIndex int, colKey string, value any, text string) {
+	files, ok := fileValues(value)
+	if !ok {
+		r.text(builder, text)
+		return
+	}
+	for _, file := range files {
+		attrs := []string{"class", "extable-file"}
+		if file.Size > 0 {
+			attrs = append(attrs, "data-size", strconv.FormatInt(file.Size, 10))
+		}
+		if file.Href != "" {
+			if href, allowed := r.opts.urlPolicy().sanitize(file.Href); allowed {
+				builder.openTag("a", append(attrs, "href", href)...)
+				r.text(builder, file.Name)
+				builder.closeTag("a")
+				continue
+			}
+			r.warn(rowIndex, colKey, "file href rejected by url policy")
+		}
+		builder.openTag("span", attrs...)
+		r.text(builder, file.Name)
+		builder.closeTag("span")
+	}
+}
+
+func (r *tableRenderer[T]) renderFileInput(builder *htmlBuilder, rowIndex int, col Column[T], name, label string, value any) {
+	r.renderFiles(builder, rowIndex, col.Key, value, "")
+	attrs := []string{"type", "file", "class", "extable-input", "name", name, "aria-label", label}
+	if spec := col.File; spec != nil {
+		if len(spec.Accept) > 0 {
+			attrs = append(attrs, "accept", strings.Join(spec.Accept, ","))
+		}
+		if spec.MaxSize > 0 {
+			attrs = append(attrs, "data-max-size", strconv.FormatInt(spec.MaxSize, 10))
+		}
+		if spec.Multiple {
+			attrs = append(attrs, "multiple", "")
+		}
+	}
+	builder.voidTag("input", attrs...)
+}
+
+func hasFileColumn[T any](columns []Column[T]) bool {
+	for _, col := range columns {
+		if col.Type == ColumnTypeFile {
+			return true
+		}
+	}
+	return false
+}
+
+// accepts applies the spec the way the server should, since the browser's
+// accept and size hints are easy to bypass.
+func (s *FileSpec) accepts(file *multipart.FileHeader) (bool, string) {
+	if s == nil {
+		return true, ""
+	}
+	if s.MaxSize > 0 && file.Size > s.MaxSize {
+		return false, "exceeds " + strconv.FormatInt(s.MaxSize, 10) + " bytes"
+	}
+	if len(s.Accept) == 0 {
+		return true, ""
+	}
+	ext := strings.ToLower(path.Ext(file.Filename))
+	mimeType := strings.ToLower(file.Header.Get("Content-Type"))
+	if parsed, _, ok := strings.Cut(mimeType, ";"); ok {
+		mimeType = strings.TrimSpace(parsed)
+	}
+	for _, accept := range s.Accept {
+		accept = strings.ToLower(strings.TrimSpace(accept))
+		switch {
+		case strings.HasPrefix(accept, "."):
+			if ext == accept {
+				return true, ""
+			}
+		case strings.HasSuffix(accept, "/*"):
+			if strings.HasPrefix(mimeType, strings.TrimSuffix(accept, "*")) {
+				return true, ""
+			}
+		case accept == mimeType:
+			return true, ""
+		}
+	}
+	return false, "type not accepted"
+}
+
+// DecodeMultipartEdits is DecodeFormEdits for a multipart form: it also
+// returns an edit per file column, whose Value is the []*multipart.FileHeader
+// that passed the column's FileSpec. Rejected files produce warnings.
+func DecodeMultipartEdits[T any](form *multipart.Form, schema Schema[T]) ([]CellEdit, []Warning) {
+	edits, warnings := DecodeFormEdits(form.Value, schema)
+	schema = schema.withDynamicColumns(nil)
+	files := make(map[string]Column[T])
+	for _, col := range schema.Columns {
+		if col.Type == ColumnTypeFile && !col.Readonly && !col.derived() && schema.columnVisible(col) {
+			files[col.Key] = col
+		}
+	}
+	for name, headers := range form.File {
+		rowKey, colKey, ok := parseFormFieldName(name)
+		col, known := files[colKey]
+		if !ok || !known {
+			continue
+		}
+		accepted := make([]*multipart.FileHeader, 0, len(headers))
+		for _, header := range headers {
+			if ok, reason := col.File.accepts(header); !ok {
+				warnings = append(warnings, Warning{RowIndex: -1, ColKey: colKey, Message: "file " + strconv.Quote(header.Filename) + " for row " + strconv.Quote(rowKey) + " rejected: " + reason})
+				continue
+			}
+			accepted = append(accepted, header)
+		}
+		if len(accepted) > 0 {
+			edits = append(edits, CellEdit{RowKey: rowKey, ColKey: colKey, Value: accepted})
+		}
+	}
+	sortEdits(edits, schema.Columns)
+	return edits, warnings
+}
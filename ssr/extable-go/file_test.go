@@ -0,0 +1,76 @@
+package extable
+
+import (
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type documentRow struct {
+	ID         string      `json:"id"`
+	Attachment []FileValue `json:"attachment"`
+}
+
+var fileSchema = Schema[documentRow]{
+	Columns: []Column[documentRow]{{
+		Key:  "attachment",
+		Type: ColumnTypeFile,
+		File: &FileSpec{Accept: []string{".pdf", "image/*"}, MaxSize: 1024, Multiple: true},
+	}},
+	RowID: func(row documentRow) string { return row.ID },
+}
+
+func TestFileColumn(t *testing.T) {
+	rows := []documentRow{{ID: "d1", Attachment: []FileValue{{Name: "a.pdf", Href: "/files/a.pdf", Size: 10}, {Name: "b.pdf", Href: "javascript:alert(1)"}}}}
+	display, err := RenderTableHTML(rows, fileSchema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(display.HTML, `<a class="extable-file" data-size="10" href="/files/a.pdf">a.pdf</a><span class="extable-file">b.pdf</span>`) {
+		t.Fatalf("expected file links, got %s", display.HTML)
+	}
+	if len(display.Metadata.Warnings) != 1 {
+		t.Fatalf("expected url policy warning, got %+v", display.Metadata.Warnings)
+	}
+
+	form, err := RenderTableHTML(rows, fileSchema, Options{FormMode: &FormMode{Action: "/upload"}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(form.HTML, `enctype="multipart/form-data"`) {
+		t.Fatalf("expected multipart form, got %s", form.HTML)
+	}
+	if !strings.Contains(form.HTML, `<input type="file" class="extable-input" name="cell[d1][attachment]" aria-label="attachment" accept=".pdf,image/*" data-max-size="1024" multiple="">`) {
+		t.Fatalf("expected file input, got %s", form.HTML)
+	}
+}
+
+func TestDecodeMultipartEdits(t *testing.T) {
+	header := func(name, contentType string, size int64) *multipart.FileHeader {
+		return &multipart.FileHeader{Filename: name, Size: size, Header: textproto.MIMEHeader{"Content-Type": {contentType}}}
+	}
+	form := &multipart.Form{
+		Value: url.Values{},
+		File: map[string][]*multipart.FileHeader{
+			"cell[d1][attachment]": {
+				header("report.PDF", "application/pdf", 100),
+				header("photo.png", "image/png", 100),
+				header("big.pdf", "application/pdf", 4096),
+				header("script.sh", "text/x-sh", 10),
+			},
+		},
+	}
+	edits, warnings := DecodeMultipartEdits(form, fileSchema)
+	if len(edits) != 1 || edits[0].RowKey != "d1" {
+		t.Fatalf("expected one file edit, got %+v", edits)
+	}
+	files := edits[0].Value.([]*multipart.FileHeader)
+	if len(files) != 2 || files[0].Filename != "report.PDF" || files[1].Filename != "photo.png" {
+		t.Fatalf("unexpected accepted files %+v", files)
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0].Message, "exceeds 1024 bytes") || !strings.Contains(warnings[1].Message, "type not accepted") {
+		t.Fatalf("unexpected warnings %+v", warnings)
+	}
+}
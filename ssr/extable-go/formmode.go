@@ -0,0 +1,357 @@
+package extable
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormMode renders the table inside a <form> with a named control in every
+// editable cell, so edits can be submitted without JavaScript. Controls are
+// named cell[<row>][<col>], where <row> is Schema.RowID or the row index;
+// DecodeFormEdits reads them back.
+type FormMode struct {
+	Action string
+	// Method is "post" when empty.
+	Method string
+}
+
+func (f *FormMode) method() string {
+	if f.Method == "" {
+		return "post"
+	}
+	return f.Method
+}
+
+func (r *tableRenderer[T]) openForm(builder *htmlBuilder) {
+	form := r.opts.FormMode
+	attrs := []string{"class", "extable-form", "action", form.Action, "method", form.method()}
+	if hasFileColumn(r.columns) {
+		attrs = append(attrs, "enctype", "multipart/form-data")
+	}
+	builder.openTag("form", attrs...)
+	if csrf := r.opts.CSRF; csrf != nil {
+		builder.voidTag("input", "type", "hidden", "name", csrf.fieldName(), "value", csrf.Token)
+	}
+}
+
+func (r *tableRenderer[T]) closeForm(builder *htmlBuilder) {
+	builder.openTag("div", "class", "extable-form-actions")
+	builder.openTag("button", "type", "submit", "class", "extable-form-submit")
+	builder.text(r.opts.messages().Submit)
+	builder.closeTag("button")
+	builder.closeTag("div")
+	builder.closeTag("form")
+}
+
+// Brackets and % are escaped in field names so keys containing "][" cannot
+// shift the boundary between row and column.
+var (
+	fieldKeyEscaper   = strings.NewReplacer("%", "%25", "[", "%5B", "]", "%5D")
+	fieldKeyUnescaper = strings.NewReplacer("%25", "%", "%5B", "[", "%5D", "]")
+)
+
+func formFieldName(rowKey, colKey string) string {
+	return "cell[" + fieldKeyEscaper.Replace(rowKey) + "][" + fieldKeyEscaper.Replace(colKey) + "]"
+}
+
+func (r *tableRenderer[T]) rowKey(rowIndex int, row T) string {
+	if r.rowID != nil {
+		return r.rowID(row)
+	}
+	return strconv.Itoa(rowIndex)
+}
+
+// hasFormControl reports whether the column type has a form control; other
+// types render as in display mode even when editable.
+func hasFormControl(colType ColumnType) bool {
+	switch colType {
+	case ColumnTypeString, ColumnTypeNumber, ColumnTypeInt, ColumnTypeUint, ColumnTypeBoolean,
+		ColumnTypeDate, ColumnTypeTime, ColumnTypeDateTime, ColumnTypeEnum, ColumnTypeEnumSet,
+		ColumnTypeTags, ColumnTypeRichText, ColumnTypeFile:
+		return true
+	}
+	return false
+}
+
+func (r *tableRenderer[T]) renderFormControl(builder *htmlBuilder, rowIndex int, colIndex int, row T, value any) {
+	col := r.columns[colIndex]
+	r.renderNamedControl(builder, rowIndex, col, formFieldName(r.rowKey(rowIndex, row), col.Key), value)
+}
+
+func (r *tableRenderer[T]) renderNamedControl(builder *htmlBuilder, rowIndex int, col Column[T], name string, value any) {
+	label := columnHeader(col)
+	switch col.Type {
+	case ColumnTypeBoolean:
+		if col.Format != nil && col.Format.BooleanTristate {
+			r.renderTristateSelect(builder, col, name, label, value)
+			return
+		}
+		checked, _ := booleanValue(value, col.Format)
+		// The hidden field submits false when the box is unchecked.
+		builder.voidTag("input", "type", "hidden", "name", name, "value", "false")
+		attrs := []string{"type", "checkbox", "class", "extable-input", "name", name, "value", "true", "aria-label", label}
+		if checked {
+			attrs = append(attrs, "checked", "")
+		}
+		if value == nil {
+			// Markup cannot set the indeterminate property; the client script reads this.
+			attrs = append(attrs, "data-indeterminate", "")
+		}
+		builder.voidTag("input", attrs...)
+	case ColumnTypeEnum, ColumnTypeEnumSet:
+		selected := make(map[string]bool)
+		switch v := value.(type) {
+		case string:
+			selected[v] = true
+		case []string:
+			for _, item := range v {
+				selected[item] = true
+			}
+		}
+		attrs := []string{"class", "extable-input", "name", name, "aria-label", label}
+		if col.Type == ColumnTypeEnumSet {
+			attrs = append(attrs, "multiple", "")
+			// A multi-select with nothing selected submits nothing; the empty
+			// hidden value lets DecodeFormEdits clear the set.
+			builder.voidTag("input", "type", "hidden", "name", name, "value", "")
+		}
+		builder.openTag("select", attrs...)
+		if col.Type == ColumnTypeEnum {
+			builder.openTag("option", "value", "")
+			builder.closeTag("option")
+		}
+		if col.Enum != nil {
+			for _, option := range col.Enum.allowedValues() {
+				optionAttrs := []string{"value", option}
+				if selected[option] {
+					optionAttrs = append(optionAttrs, "selected", "")
+				}
+				builder.openTag("option", optionAttrs...)
+				text := option
+				if labelText, ok := col.Enum.Labels[option]; ok {
+					text = labelText
+				}
+				builder.text(text)
+				builder.closeTag("option")
+			}
+		}
+		builder.closeTag("select")
+	case ColumnTypeFile:
+		r.renderFileInput(builder, rowIndex, col, name, label, value)
+	case ColumnTypeRichText:
+		r.renderTextarea(builder, name, label, value)
+	case ColumnTypeString:
+		if col.WrapText || col.MaxLines > 0 {
+			r.renderTextarea(builder, name, label, value)
+			return
+		}
+		fallthrough
+	default:
+		attrs := append(formInputType(col.Type), "class", "extable-input", "name", name, "value", formInputValue(value, col, r.opts), "aria-label", label)
+		builder.voidTag("input", attrs...)
+	}
+}
+
+func (r *tableRenderer[T]) renderTristateSelect(builder *htmlBuilder, col Column[T], name, label string, value any) {
+	current := ""
+	if v, ok := booleanValue(value, col.Format); ok {
+		current = strconv.FormatBool(v)
+	}
+	builder.openTag("select", "class", "extable-input", "name", name, "aria-label", label)
+	for _, option := range []string{"", "true", "false"} {
+		attrs := []string{"value", option}
+		if option == current {
+			attrs = append(attrs, "selected", "")
+		}
+		builder.openTag("option", attrs...)
+		if option == "" {
+			builder.text(booleanUnknownLabel(col.Format, r.opts.messages()))
+		} else {
+			builder.text(formatBoolean(option == "true", col.Format, r.opts.messages()))
+		}
+		builder.closeTag("option")
+	}
+	builder.closeTag("select")
+}
+
+func (r *tableRenderer[T]) renderTextarea(builder *htmlBuilder, name, label string, value any) {
+	builder.openTag("textarea", "class", "extable-input", "name", name, "aria-label", label)
+	if value != nil {
+		builder.text(stringifyValue(value))
+	}
+	builder.closeTag("textarea")
+}
+
+func formInputType(colType ColumnType) []string {
+	switch colType {
+	case ColumnTypeNumber:
+		return []string{"type", "number", "step", "any"}
+	case ColumnTypeInt:
+		return []string{"type", "number", "step", "1"}
+	case ColumnTypeUint:
+		return []string{"type", "number", "step", "1", "min", "0"}
+	case ColumnTypeDate:
+		return []string{"type", "date"}
+	case ColumnTypeTime:
+		return []string{"type", "time", "step", "1"}
+	case ColumnTypeDateTime:
+		return []string{"type", "datetime-local", "step", "1"}
+	}
+	return []string{"type", "text"}
+}
+
+// formInputValue formats value the way the browser's control expects, which
+// for numbers and dates is a fixed machine format rather than the display format.
+func formInputValue[T any](value any, col Column[T], opts *Options) string {
+	if value == nil {
+		return ""
+	}
+	switch col.Type {
+	case ColumnTypeNumber, ColumnTypeInt, ColumnTypeUint:
+		// Big and decimal values keep every digit so a submit does not round them.
+		if text, ok := formatBigNumber(value, -1); ok {
+			return text
+		}
+		if n, ok := integerValue(value); ok {
+			return strconv.FormatInt(n, 10)
+		}
+		if n, ok := numericValue(value); ok {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	case ColumnTypeDate, ColumnTypeTime, ColumnTypeDateTime:
+		if t, ok := timeValue(value, col.Format); ok {
+			if col.Type == ColumnTypeDateTime && opts.Location != nil {
+				t = t.In(opts.Location)
+			}
+			layout := map[ColumnType]string{
+				ColumnTypeDate:     "2006-01-02",
+				ColumnTypeTime:     "15:04:05",
+				ColumnTypeDateTime: "2006-01-02T15:04:05",
+			}[col.Type]
+			return t.Format(layout)
+		}
+	case ColumnTypeTags:
+		if tags, ok := value.([]string); ok {
+			return strings.Join(tags, tagsSeparator(col))
+		}
+	}
+	return formatValue(value, col, opts)
+}
+
+func tagsSeparator[T any](col Column[T]) string {
+	if col.Tags != nil && col.Tags.Separator != "" {
+		return col.Tags.Separator
+	}
+	return ", "
+}
+
+// CellEdit is one submitted form control. Value is converted to the column
+// type as LoadCSV does: []string for enumset and tags, nil for blank input.
+type CellEdit struct {
+	RowKey string
+	ColKey string
+	Value  any
+}
+
+// DecodeFormEdits reads the cell[<row>][<col>] fields of a form submitted in
+// FormMode. Fields for unknown, hidden or non-editable columns are ignored;
+// row locks (the _readonly field and Schema.RowReadonlyReason) depend on row
+// data this function does not see, so callers must re-check them. Edits are
+// ordered by row key, then by column order in schema; values that cannot be
+// converted keep their text and produce a warning naming the row key.
+// Datetime inputs carry no zone: when the form was rendered with
+// Options.Location, read the wall-clock time of the decoded value in that
+// location.
+func DecodeFormEdits[T any](form url.Values, schema Schema[T]) ([]CellEdit, []Warning) {
+	schema = schema.withDynamicColumns(nil)
+	index := make(map[string]int, len(schema.Columns))
+	for i, col := range schema.Columns {
+		if !schema.columnVisible(col) {
+			continue
+		}
+		if !col.Readonly && !col.derived() && hasFormControl(col.Type) && col.Type != ColumnTypeFile {
+			index[col.Key] = i
+		}
+	}
+	edits := make([]CellEdit, 0)
+	for name, values := range form {
+		rowKey, colKey, ok := parseFormFieldName(name)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if _, known := index[colKey]; !known {
+			continue
+		}
+		edits = append(edits, CellEdit{RowKey: rowKey, ColKey: colKey, Value: values})
+	}
+	sortEdits(edits, schema.Columns)
+	warnings := make([]Warning, 0)
+	for i := range edits {
+		col := schema.Columns[index[edits[i].ColKey]]
+		values := edits[i].Value.([]string)
+		switch col.Type {
+		case ColumnTypeEnumSet:
+			selected := make([]string, 0, len(values))
+			for _, value := range values {
+				if value != "" {
+					selected = append(selected, value)
+				}
+			}
+			edits[i].Value = selected
+		case ColumnTypeTags:
+			edits[i].Value = splitTags(values[len(values)-1], col)
+		default:
+			// A checkbox submits the hidden "false" followed by "true"; the last value wins.
+			text := values[len(values)-1]
+			value, ok := coerceText(text, col)
+			if !ok {
+				warning := coercionWarning(-1, col, text)
+				warning.Message += " for row " + strconv.Quote(edits[i].RowKey)
+				warnings = append(warnings, warning)
+			}
+			edits[i].Value = value
+		}
+	}
+	return edits, warnings
+}
+
+func sortEdits[T any](edits []CellEdit, columns []Column[T]) {
+	order := make(map[string]int, len(columns))
+	for i, col := range columns {
+		order[col.Key] = i
+	}
+	sort.SliceStable(edits, func(a, b int) bool {
+		if edits[a].RowKey != edits[b].RowKey {
+			return lessRowKey(edits[a].RowKey, edits[b].RowKey)
+		}
+		return order[edits[a].ColKey] < order[edits[b].ColKey]
+	})
+}
+
+func parseFormFieldName(name string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(name, "cell[")
+	if !ok || !strings.HasSuffix(rest, "]") {
+		return "", "", false
+	}
+	rowKey, colKey, ok := strings.Cut(strings.TrimSuffix(rest, "]"), "][")
+	if !ok || rowKey == "" || colKey == "" || strings.ContainsAny(colKey, "[]") {
+		return "", "", false
+	}
+	return fieldKeyUnescaper.Replace(rowKey), fieldKeyUnescaper.Replace(colKey), true
+}
+
+// lessRowKey orders numeric row keys numerically before all others, which
+// are ordered lexically.
+func lessRowKey(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	if (errA == nil) != (errB == nil) {
+		return errA == nil
+	}
+	return a < b
+}
@@ -0,0 +1,179 @@
+package extable
+
+import (
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type formRow struct {
+	ID     string    `json:"id"`
+	Name   string    `json:"name"`
+	Qty    int       `json:"qty"`
+	Active bool      `json:"active"`
+	Due    time.Time `json:"due"`
+	Status string    `json:"status"`
+}
+
+var formSchema = Schema[formRow]{
+	Columns: []Column[formRow]{
+		{Key: "id", Type: ColumnTypeString, Readonly: true},
+		{Key: "name", Type: ColumnTypeString, Header: "Name"},
+		{Key: "qty", Type: ColumnTypeInt},
+		{Key: "active", Type: ColumnTypeBoolean},
+		{Key: "due", Type: ColumnTypeDate},
+		{Key: "status", Type: ColumnTypeEnum, Enum: &EnumSpec{Values: []string{"open", "done"}, Labels: map[string]string{"done": "Done"}}},
+	},
+	RowID: func(row formRow) string { return row.ID },
+}
+
+func TestFormMode(t *testing.T) {
+	rows := []formRow{{ID: "r1", Name: "Widget", Qty: 3, Active: true, Due: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Status: "done"}}
+	result, err := RenderTableHTML(rows, formSchema, Options{
+		FormMode: &FormMode{Action: "/items"},
+		CSRF:     &CSRF{Token: "tok"},
+	})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{
+		`<form class="extable-form" action="/items" method="post"><input type="hidden" name="csrf_token" value="tok"><table`,
+		`data-col-key="id">r1</td>`,
+		`<input type="text" class="extable-input" name="cell[r1][name]" value="Widget" aria-label="Name">`,
+		`<input type="number" step="1" class="extable-input" name="cell[r1][qty]" value="3" aria-label="qty">`,
+		`<input type="hidden" name="cell[r1][active]" value="false"><input type="checkbox" class="extable-input" name="cell[r1][active]" value="true" aria-label="active" checked="">`,
+		`<input type="date" class="extable-input" name="cell[r1][due]" value="2024-03-01" aria-label="due">`,
+		`<select class="extable-input" name="cell[r1][status]" aria-label="status"><option value=""></option><option value="open">open</option><option value="done" selected="">Done</option></select>`,
+		`</table><div class="extable-form-actions"><button type="submit" class="extable-form-submit">Save</button></div></form>`,
+	} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s in %s", want, result.HTML)
+		}
+	}
+}
+
+func TestDecodeFormEdits(t *testing.T) {
+	form := url.Values{
+		"cell[r2][qty]":    {"x"},
+		"cell[r1][qty]":    {"7"},
+		"cell[r1][active]": {"false", "true"},
+		"cell[r1][id]":     {"hacked"},
+		"cell[r1][due]":    {"2024-04-05"},
+		"csrf_token":       {"tok"},
+	}
+	edits, warnings := DecodeFormEdits(form, formSchema)
+	if len(edits) != 4 {
+		t.Fatalf("expected four edits, got %+v", edits)
+	}
+	if edits[0].RowKey != "r1" || edits[0].ColKey != "qty" || edits[0].Value != int64(7) {
+		t.Fatalf("unexpected first edit %+v", edits[0])
+	}
+	if edits[1].ColKey != "active" || edits[1].Value != true {
+		t.Fatalf("expected checked box to decode as true, got %+v", edits[1])
+	}
+	if due, ok := edits[2].Value.(time.Time); !ok || due.Day() != 5 {
+		t.Fatalf("expected parsed date, got %+v", edits[2])
+	}
+	if edits[3].RowKey != "r2" || edits[3].Value != "x" || len(warnings) != 1 || !strings.Contains(warnings[0].Message, `for row "r2"`) {
+		t.Fatalf("expected invalid qty to warn, got %+v %+v", edits[3], warnings)
+	}
+}
+
+func TestFormFieldNameRoundTrip(t *testing.T) {
+	for _, rowKey := range []string{"r1", "a]b", "x][qty", "[", "50%", "%5D"} {
+		rowGot, colGot, ok := parseFormFieldName(formFieldName(rowKey, "name"))
+		if !ok || rowGot != rowKey || colGot != "name" {
+			t.Fatalf("round trip of %q gave %q %q %v", rowKey, rowGot, colGot, ok)
+		}
+	}
+	schema := formSchema
+	schema.RowID = func(row formRow) string { return "a][qty" }
+	result, err := RenderTableHTML([]formRow{{Name: "n"}}, schema, Options{FormMode: &FormMode{}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `name="cell[a%5D%5Bqty][name]"`) {
+		t.Fatalf("expected an escaped row key: %s", result.HTML)
+	}
+	edits, _ := DecodeFormEdits(url.Values{"cell[a%5D%5Bqty][name]": {"m"}}, schema)
+	if len(edits) != 1 || edits[0].RowKey != "a][qty" || edits[0].ColKey != "name" {
+		t.Fatalf("escaped key decoded wrongly: %+v", edits)
+	}
+}
+
+func TestEnumSetClearable(t *testing.T) {
+	type tagged struct {
+		Labels []string `json:"labels"`
+	}
+	schema := Schema[tagged]{Columns: []Column[tagged]{
+		{Key: "labels", Type: ColumnTypeEnumSet, Enum: &EnumSpec{Values: []string{"a", "b"}}},
+	}}
+	result, err := RenderTableHTML([]tagged{{Labels: []string{"a"}}}, schema, Options{FormMode: &FormMode{}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<input type="hidden" name="cell[0][labels]" value=""><select class="extable-input" name="cell[0][labels]"`) {
+		t.Fatalf("expected an empty sentinel before the select: %s", result.HTML)
+	}
+	edits, _ := DecodeFormEdits(url.Values{"cell[0][labels]": {""}}, schema)
+	if len(edits) != 1 || len(edits[0].Value.([]string)) != 0 {
+		t.Fatalf("expected the set to be cleared: %+v", edits)
+	}
+	edits, _ = DecodeFormEdits(url.Values{"cell[0][labels]": {"", "b"}}, schema)
+	if got := edits[0].Value.([]string); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("expected only the selected value: %+v", got)
+	}
+}
+
+type entryRow struct {
+	ID     string    `json:"id"`
+	Amount *big.Rat  `json:"amount"`
+	Total  *big.Int  `json:"total"`
+	At     time.Time `json:"at"`
+}
+
+func TestFormInputValuesExact(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	amount, _ := new(big.Rat).SetString("12345678901234567.89")
+	total, _ := new(big.Int).SetString("123456789012345678901", 10)
+	schema := Schema[entryRow]{
+		Columns: []Column[entryRow]{
+			{Key: "amount", Type: ColumnTypeNumber},
+			{Key: "total", Type: ColumnTypeInt},
+			{Key: "at", Type: ColumnTypeDateTime},
+		},
+		RowID: func(row entryRow) string { return row.ID },
+	}
+	rows := []entryRow{{ID: "l1", Amount: amount, Total: total, At: time.Date(2024, 3, 1, 1, 30, 0, 0, time.UTC)}}
+	result, err := RenderTableHTML(rows, schema, Options{FormMode: &FormMode{Action: "/ledger"}, Location: tokyo})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{
+		`name="cell[l1][amount]" value="12345678901234567.89"`,
+		`name="cell[l1][total]" value="123456789012345678901"`,
+		`name="cell[l1][at]" value="2024-03-01T10:30:00"`,
+	} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s in %s", want, result.HTML)
+		}
+	}
+}
+
+func TestLessRowKeyMixed(t *testing.T) {
+	keys := []string{"b", "10", "a1", "2", "r", "-3"}
+	sort.Slice(keys, func(i, j int) bool { return lessRowKey(keys[i], keys[j]) })
+	if got := strings.Join(keys, " "); got != "-3 2 10 a1 b r" {
+		t.Fatalf("expected numeric keys first, got %s", got)
+	}
+	for _, a := range keys {
+		for _, b := range keys {
+			if lessRowKey(a, b) && lessRowKey(b, a) {
+				t.Fatalf("lessRowKey is not asymmetric for %q and %q", a, b)
+			}
+		}
+	}
+}
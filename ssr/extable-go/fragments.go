@@ -0,0 +1,40 @@
+package extable
+
+// RenderRowFragments renders each row as a standalone <tr> fragment for
+// clients that patch rows into an already rendered table. Fragments line up
+// 1:1 with data, so Sort, Offset, Limit, Schema.RowFilter and Schema.Children
+// are ignored; row headers number rows by their position in data.
+func RenderRowFragments[T any](data []T, schema Schema[T], opts Options) ([]string, error) {
+	return renderFragments(data, 0, schema, opts)
+}
+
+// RenderAppend renders the <tr> fragments for rows appended after startIndex
+// rows already on the page, for infinite scroll. Row numbers, striping and
+// keyboard-nav positions continue from startIndex. As with RenderRowFragments,
+// fragments line up 1:1 with data.
+func RenderAppend[T any](data []T, startIndex int, schema Schema[T], opts Options) ([]string, error) {
+	if startIndex < 0 {
+		return nil, &OptionsError{Problems: []string{"startIndex must not be negative"}}
+	}
+	return renderFragments(data, startIndex, schema, opts)
+}
+
+func renderFragments[T any](data []T, startIndex int, schema Schema[T], opts Options) ([]string, error) {
+	compiled, err := compileSchema(schema.withDynamicColumns(data))
+	if err != nil {
+		return nil, err
+	}
+	compiled.schema.RowFilter = nil
+	opts.rowCache = nil
+	r := compiled.visible().newTableRenderer(data, &opts)
+	r.position = startIndex
+	r.band = startIndex
+	fragments := make([]string, len(data))
+	for i, row := range data {
+		builder := newHTMLBuilder(opts.Indent, 0)
+		builder.classPrefix = opts.ClassPrefix
+		r.renderRow(builder, startIndex+i, row, nil)
+		fragments[i] = builder.string()
+	}
+	return fragments, nil
+}
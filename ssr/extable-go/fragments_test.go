@@ -0,0 +1,29 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderAppend(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	opts := Options{Stripe: &Stripe{}, KeyboardNav: true}
+	all := []sampleRow{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	full, err := RenderRowFragments(all, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	appended, err := RenderAppend(all[2:], 2, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if len(appended) != 1 || appended[0] != full[2] {
+		t.Fatalf("appended row differs from full render:\n%v\n%s", appended, full[2])
+	}
+	if !strings.Contains(appended[0], ">3</th>") {
+		t.Fatalf("expected continued row number: %s", appended[0])
+	}
+	if _, err := RenderAppend(all, -1, schema, opts); err == nil {
+		t.Fatalf("expected negative startIndex to be rejected")
+	}
+}
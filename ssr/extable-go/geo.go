@@ -0,0 +1,126 @@
+package extable
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type GeoNotation string
+
+const (
+	GeoDecimal GeoNotation = "decimal"
+	GeoDMS     GeoNotation = "dms"
+)
+
+type GeoSpec struct {
+	// Notation is decimal degrees when empty.
+	Notation GeoNotation `json:"notation,omitempty"`
+	// Precision is the number of decimals: of degrees for decimal notation
+	// (5 when zero), of seconds for DMS (1 when zero).
+	Precision int `json:"precision,omitempty"`
+	// MapHref links the cell to a map; {lat} and {lng} expand to decimal
+	// degrees, e.g. "https://www.openstreetmap.org/?mlat={lat}&mlon={lng}".
+	MapHref string `json:"mapHref,omitempty"`
+}
+
+// geoValue accepts GeoPoint, [2]float64 and []float64 as {lat, lng}, and
+// "lat,lng" strings. Points outside the valid ranges are rejected.
+func geoValue(value any) (GeoPoint, bool) {
+	var point GeoPoint
+	switch v := value.(type) {
+	case GeoPoint:
+		point = v
+	case *GeoPoint:
+		if v == nil {
+			return GeoPoint{}, false
+		}
+		point = *v
+	case [2]float64:
+		point = GeoPoint{Lat: v[0], Lng: v[1]}
+	case []float64:
+		if len(v) != 2 {
+			return GeoPoint{}, false
+		}
+		point = GeoPoint{Lat: v[0], Lng: v[1]}
+	case string:
+		latText, lngText, ok := strings.Cut(v, ",")
+		if !ok {
+			return GeoPoint{}, false
+		}
+		lat, errLat := strconv.ParseFloat(strings.TrimSpace(latText), 64)
+		lng, errLng := strconv.ParseFloat(strings.TrimSpace(lngText), 64)
+		if errLat != nil || errLng != nil {
+			return GeoPoint{}, false
+		}
+		point = GeoPoint{Lat: lat, Lng: lng}
+	default:
+		return GeoPoint{}, false
+	}
+	if math.Abs(point.Lat) > 90 || math.Abs(point.Lng) > 180 || math.IsNaN(point.Lat) || math.IsNaN(point.Lng) {
+		return GeoPoint{}, false
+	}
+	return point, true
+}
+
+func formatGeo(point GeoPoint, spec *GeoSpec) string {
+	if spec != nil && spec.Notation == GeoDMS {
+		precision := 1
+		if spec.Precision > 0 {
+			precision = spec.Precision
+		}
+		return dms(point.Lat, "N", "S", precision) + " " + dms(point.Lng, "E", "W", precision)
+	}
+	precision := 5
+	if spec != nil && spec.Precision > 0 {
+		precision = spec.Precision
+	}
+	return strconv.FormatFloat(point.Lat, 'f', precision, 64) + ", " + strconv.FormatFloat(point.Lng, 'f', precision, 64)
+}
+
+func dms(degrees float64, positive, negative string, precision int) string {
+	hemisphere := positive
+	if degrees < 0 {
+		hemisphere = negative
+		degrees = -degrees
+	}
+	scale := math.Pow(10, float64(precision))
+	// Round once in seconds so 59.99″ carries into the minutes.
+	total := math.Round(degrees*3600*scale) / scale
+	d := math.Floor(total / 3600)
+	m := math.Floor((total - d*3600) / 60)
+	s := total - d*3600 - m*60
+	return fmt.Sprintf("%.0f°%.0f′%s″%s", d, m, strconv.FormatFloat(s, 'f', precision, 64), hemisphere)
+}
+
+func (r *tableRenderer[T]) renderGeo(builder *htmlBuilder, rowIndex int, col Column[T], value any, text string) {
+	point, ok := geoValue(value)
+	if !ok || col.Geo == nil || col.Geo.MapHref == "" {
+		r.text(builder, text)
+		return
+	}
+	href := strings.NewReplacer(
+		"{lat}", url.QueryEscape(strconv.FormatFloat(point.Lat, 'f', -1, 64)),
+		"{lng}", url.QueryEscape(strconv.FormatFloat(point.Lng, 'f', -1, 64)),
+	).Replace(col.Geo.MapHref)
+	r.renderLink(builder, rowIndex, col.Key, LinkValue{Label: text, Href: href}, text)
+}
+
+func checkGeoSpecs[T any](columns []Column[T]) error {
+	for _, col := range columns {
+		if col.Geo == nil {
+			continue
+		}
+		if col.Geo.Notation != "" && col.Geo.Notation != GeoDecimal && col.Geo.Notation != GeoDMS {
+			return &SchemaError{ColKey: col.Key, Message: fmt.Sprintf("has unknown geo notation %q", col.Geo.Notation)}
+		}
+	}
+	return nil
+}
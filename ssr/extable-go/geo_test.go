@@ -0,0 +1,42 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatGeo(t *testing.T) {
+	tokyo := GeoPoint{Lat: 35.681236, Lng: 139.767125}
+	if got := formatGeo(tokyo, nil); got != "35.68124, 139.76712" {
+		t.Fatalf("unexpected decimal: %q", got)
+	}
+	if got := formatGeo(GeoPoint{Lat: -33.8688, Lng: -0.99999}, &GeoSpec{Notation: GeoDMS}); got != "33°52′7.7″S 1°0′0.0″W" {
+		t.Fatalf("unexpected dms: %q", got)
+	}
+	if _, ok := geoValue("91,0"); ok {
+		t.Fatalf("expected out-of-range latitude to be rejected")
+	}
+	if point, ok := geoValue(" 1.5, -2 "); !ok || point != (GeoPoint{Lat: 1.5, Lng: -2}) {
+		t.Fatalf("unexpected parse: %v %v", point, ok)
+	}
+}
+
+func TestGeoColumn(t *testing.T) {
+	type asset struct {
+		Pos GeoPoint `json:"pos"`
+	}
+	schema := Schema[asset]{Columns: []Column[asset]{
+		{Key: "pos", Type: ColumnTypeGeo, Geo: &GeoSpec{Precision: 2, MapHref: "https://www.openstreetmap.org/?mlat={lat}&mlon={lng}"}},
+	}}
+	result, err := RenderTableHTML([]asset{{Pos: GeoPoint{Lat: 35.5, Lng: -139.25}}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<a class="extable-action-link" href="https://www.openstreetmap.org/?mlat=35.5&amp;mlon=-139.25">35.50, -139.25</a>`) {
+		t.Fatalf("missing map link: %s", result.HTML)
+	}
+	schema.Columns[0].Geo.Notation = "utm"
+	if err := schema.Validate(); err == nil {
+		t.Fatalf("expected unknown notation to be rejected")
+	}
+}
@@ -1,3 +1,14 @@
 module github.com/shibukawayoshiki/extable/ssr/extable-go
 
 go 1.22
+
+require (
+	github.com/expr-lang/expr v1.17.8
+	github.com/microcosm-cc/bluemonday v1.0.27
+	golang.org/x/net v0.26.0
+)
+
+require (
+	github.com/aymerick/douceur v0.2.0 // indirect
+	github.com/gorilla/css v1.0.1 // indirect
+)
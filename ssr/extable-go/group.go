@@ -0,0 +1,94 @@
+package extable
+
+import "strconv"
+
+type groupState struct {
+	colIndex  int
+	keys      []string
+	counts    map[string]int
+	labels    map[string]string
+	collapsed map[string]bool
+	current   string
+	started   bool
+	// hidden is set while rows of a collapsed group are written.
+	hidden bool
+}
+
+// groupRows stably reorders data so rows sharing a GroupBy value are adjacent.
+func (c *compiledSchema[T]) groupRows(data []T, opts *Options) ([]T, *groupState, error) {
+	colIndex := -1
+	for i, col := range c.schema.Columns {
+		if col.Key == opts.GroupBy {
+			colIndex = i
+			break
+		}
+	}
+	if colIndex < 0 {
+		return nil, nil, &SchemaError{ColKey: opts.GroupBy, Message: "is the GroupBy key but not a schema column"}
+	}
+	col := c.schema.Columns[colIndex]
+	rowKeys := make([]string, len(data))
+	order := make([]string, 0)
+	members := make(map[string][]int)
+	counts := make(map[string]int)
+	labels := make(map[string]string)
+	for i, row := range data {
+		value, _ := columnValue(c.getter, row, col)
+		key := exportText(value, col)
+		rowKeys[i] = key
+		if _, ok := members[key]; !ok {
+			order = append(order, key)
+			labels[key] = formatValue(value, col, opts)
+		}
+		members[key] = append(members[key], i)
+		if keepRow(c.schema.RowFilter, row) {
+			counts[key]++
+		}
+	}
+	grouped := make([]T, 0, len(data))
+	keys := make([]string, 0, len(data))
+	for _, key := range order {
+		for _, i := range members[key] {
+			grouped = append(grouped, data[i])
+			keys = append(keys, rowKeys[i])
+		}
+	}
+	collapsed := make(map[string]bool, len(opts.CollapsedGroups))
+	for _, key := range opts.CollapsedGroups {
+		collapsed[key] = true
+	}
+	return grouped, &groupState{colIndex: colIndex, keys: keys, counts: counts, labels: labels, collapsed: collapsed}, nil
+}
+
+// rowHidden reports whether the row being written is in a collapsed group.
+// Hidden rows take no position, so they neither hold the keyboard tab stop
+// nor shift stripe bands.
+func (r *tableRenderer[T]) rowHidden() bool {
+	return r.groups != nil && r.groups.hidden
+}
+
+// renderGroupHeader writes a group header before the first rendered row of
+// each group, so paged output repeats the header of a group split across pages.
+func (r *tableRenderer[T]) renderGroupHeader(builder *htmlBuilder, dataIndex int) {
+	g := r.groups
+	key := g.keys[dataIndex]
+	if g.started && key == g.current {
+		return
+	}
+	g.started, g.current, g.hidden = true, key, g.collapsed[key]
+	r.band = 0
+	classes := []string{"extable-group-header"}
+	expanded := "true"
+	if g.hidden {
+		classes = append(classes, "extable-group-collapsed")
+		expanded = "false"
+	}
+	builder.openTag("tr", "class", joinClasses(classes), "data-group", key, "aria-expanded", expanded)
+	builder.openTag("th", "class", "extable-group-label", "colspan", strconv.Itoa(len(r.columns)+1), "scope", "rowgroup")
+	builder.text(g.labels[key])
+	builder.openTag("span", "class", "extable-group-count")
+	builder.text(strconv.Itoa(g.counts[key]))
+	builder.closeTag("span")
+	builder.closeTag("th")
+	builder.closeTag("tr")
+}
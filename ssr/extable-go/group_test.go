@@ -0,0 +1,86 @@
+package extable
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	rows := []sampleRow{{Name: "a", Age: 1}, {Name: "b", Age: 2}, {Name: "c", Age: 1}}
+	result, err := RenderTableHTML(rows, schema, Options{GroupBy: "age", CollapsedGroups: []string{"2"}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	open := `<tr class="extable-group-header" data-group="1" aria-expanded="true"><th class="extable-group-label" colspan="3" scope="rowgroup">1<span class="extable-group-count">2</span></th></tr>`
+	if !strings.Contains(html, open) {
+		t.Fatalf("missing expanded group header: %s", html)
+	}
+	if !strings.Contains(html, `<tr class="extable-group-header extable-group-collapsed" data-group="2" aria-expanded="false">`) {
+		t.Fatalf("missing collapsed group header: %s", html)
+	}
+	if strings.Index(html, ">c</td>") > strings.Index(html, `data-group="2"`) {
+		t.Fatalf("expected rows of a group to be adjacent: %s", html)
+	}
+	if strings.Count(html, `<tr hidden="">`) != 1 {
+		t.Fatalf("expected one hidden row: %s", html)
+	}
+	if result.Metadata.RowCount != 3 {
+		t.Fatalf("group headers must not count as rows: %+v", result.Metadata)
+	}
+
+	if _, err := RenderTableHTML(rows, schema, Options{GroupBy: "missing"}); err == nil {
+		t.Fatalf("expected unknown GroupBy key to be rejected")
+	}
+	if _, err := RenderTableHTML(rows, schema, Options{CollapsedGroups: []string{"x"}}); err == nil {
+		t.Fatalf("expected CollapsedGroups without GroupBy to be rejected")
+	}
+}
+
+func TestCollapsedFirstGroup(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	rows := []sampleRow{{Name: "a", Age: 1}, {Name: "b", Age: 1}, {Name: "c", Age: 2}, {Name: "d", Age: 2}}
+	opts := Options{GroupBy: "age", CollapsedGroups: []string{"1"}, KeyboardNav: true, Stripe: &Stripe{}}
+	result, err := RenderTableHTML(rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	if strings.Count(html, `tabindex="0"`) != 1 || !strings.Contains(html, `tabindex="0" data-nav="0,0">c</td>`) {
+		t.Fatalf("expected the tab stop on the first visible row: %s", html)
+	}
+	if strings.Contains(html, `data-nav="0,0">a<`) || strings.Contains(html, `extable-stripe" hidden`) {
+		t.Fatalf("expected hidden rows outside navigation and banding: %s", html)
+	}
+	if !strings.Contains(html, `<tr class="extable-stripe"><th`) || strings.Index(html, "extable-stripe") < strings.Index(html, ">c</td>") {
+		t.Fatalf("expected banding to start at the first visible row: %s", html)
+	}
+}
+
+func TestStripeBandsRestartPerGroup(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	rows := []sampleRow{{Name: "a", Age: 1}, {Name: "b", Age: 1}, {Name: "c", Age: 1}, {Name: "d", Age: 2}, {Name: "e", Age: 2}}
+	result, err := RenderTableHTML(rows, schema, Options{GroupBy: "age", Stripe: &Stripe{}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	striped := regexp.MustCompile(`<tr class="extable-stripe"><th[^>]*>\d+</th><td[^>]*>(\w+)</td>`).FindAllStringSubmatch(result.HTML, -1)
+	names := make([]string, 0, len(striped))
+	for _, m := range striped {
+		names = append(names, m[1])
+	}
+	if strings.Join(names, " ") != "b e" {
+		t.Fatalf("expected bands to restart after each group header, got %v: %s", names, result.HTML)
+	}
+}
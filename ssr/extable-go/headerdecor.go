@@ -0,0 +1,30 @@
+package extable
+
+// sortDirection reports the active sort direction for key: "asc", "desc", or
+// "" when Options.Sort does not include it.
+func (o *Options) sortDirection(key string) string {
+	for _, spec := range o.Sort {
+		if spec.Key == key {
+			if spec.Descending {
+				return "desc"
+			}
+			return "asc"
+		}
+	}
+	return ""
+}
+
+// renderSortIndicator draws the same arrow the client header uses for an
+// active sort, so hydration does not visibly change the header.
+func renderSortIndicator(builder *htmlBuilder, dir string) {
+	d := "M12 6l6 8H6l6-8z"
+	if dir == "desc" {
+		d = "M12 18l-6-8h12l-6 8z"
+	}
+	builder.openTag("span", "class", "extable-sort-indicator", "data-sort-dir", dir)
+	builder.openTag("svg", "viewBox", "0 0 24 24", "width", "16", "height", "16", "aria-hidden", "true", "focusable", "false")
+	builder.openTag("path", "d", d, "fill", "currentColor")
+	builder.closeTag("path")
+	builder.closeTag("svg")
+	builder.closeTag("span")
+}
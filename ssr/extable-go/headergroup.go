@@ -0,0 +1,42 @@
+package extable
+
+import "strconv"
+
+func hasHeaderGroups[T any](columns []Column[T]) bool {
+	for _, col := range columns {
+		if col.HeaderGroup != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// renderHeaderGroups writes the cells of the group header row, one per run
+// of adjacent columns sharing a HeaderGroup; ungrouped columns get an empty
+// cell scoped to the column below it.
+func (r *tableRenderer[T]) renderHeaderGroups(builder *htmlBuilder) {
+	classes := append([]string{"extable-header-group"}, stickyClasses(r.opts, 0, false)...)
+	for i := 0; i < len(r.columns); {
+		group := r.columns[i].HeaderGroup
+		span := 1
+		for group != "" && i+span < len(r.columns) && r.columns[i+span].HeaderGroup == group {
+			span++
+		}
+		attrs := []string{"class", joinClasses(classes)}
+		if group != "" {
+			attrs = append(attrs, "scope", "colgroup")
+		} else {
+			attrs = append(attrs, "scope", "col")
+		}
+		if span > 1 {
+			attrs = append(attrs, "colspan", strconv.Itoa(span))
+		}
+		if style := stickyStyle(r.opts, 0, false); style != nil {
+			attrs = append(attrs, "style", styleString(style))
+		}
+		builder.openTag("th", attrs...)
+		builder.text(group)
+		builder.closeTag("th")
+		i += span
+	}
+}
@@ -0,0 +1,54 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFrozenHeaderRows(t *testing.T) {
+	schema := Schema[exportRow]{Columns: []Column[exportRow]{
+		{Key: "id", Type: ColumnTypeInt},
+		{Key: "name", Type: ColumnTypeString, HeaderGroup: "Person"},
+		{Key: "active", Type: ColumnTypeBoolean, HeaderGroup: "Person"},
+	}}
+	result, err := RenderTableHTML([]exportRow{{ID: 1}}, schema, Options{FrozenHeaderRows: 2})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	for _, want := range []string{
+		`<th class="extable-row-header extable-corner extable-sticky-header" data-col-key="" rowspan="2" style="position: sticky; top: 0; z-index: 2;"></th>`,
+		`<th class="extable-header-group extable-sticky-header" scope="col" style="position: sticky; top: 0; z-index: 2;"></th>`,
+		`<th class="extable-header-group extable-sticky-header" scope="colgroup" colspan="2" style="position: sticky; top: 0; z-index: 2;">Person</th>`,
+		`<th data-col-key="id" scope="col" class="extable-sticky-header" style="position: sticky; top: calc(var(--extable-header-row-height, 2.5em) * 1); z-index: 2;">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("missing %s: %s", want, html)
+		}
+	}
+
+	result, err = RenderTableHTML([]exportRow{{ID: 1}}, schema, Options{FrozenHeaderRows: 1, StickyHeader: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Count(result.HTML, "extable-sticky-header") != 3 {
+		t.Fatalf("expected only the group row to be pinned: %s", result.HTML)
+	}
+	if _, err := RenderTableHTML([]exportRow{{ID: 1}}, schema, Options{FrozenHeaderRows: -1}); err == nil {
+		t.Fatalf("expected negative FrozenHeaderRows to be rejected")
+	}
+}
+
+func TestHeaderGroupsPassAudit(t *testing.T) {
+	schema := Schema[exportRow]{Columns: []Column[exportRow]{
+		{Key: "id", Type: ColumnTypeInt, Header: "Number"},
+		{Key: "name", Type: ColumnTypeString, HeaderGroup: "Person"},
+	}}
+	result, err := RenderTableHTML([]exportRow{{ID: 1}}, schema, Options{Caption: "People"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if issues := AuditAccessibility(result); len(issues) != 0 {
+		t.Fatalf("renderer output should pass the audit: %+v", issues)
+	}
+}
@@ -0,0 +1,153 @@
+package extable
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultHeatmapLowColor  = "#ffffff"
+	defaultHeatmapHighColor = "#63be7b"
+)
+
+type numericRange struct {
+	min float64
+	max float64
+	ok  bool
+}
+
+// add ignores NaN and ±Inf so a single bad value cannot collapse the scale.
+func (r *numericRange) add(value float64) {
+	if !isFinite(value) {
+		return
+	}
+	if !r.ok {
+		r.min = value
+		r.max = value
+		r.ok = true
+		return
+	}
+	r.min = math.Min(r.min, value)
+	r.max = math.Max(r.max, value)
+}
+
+func needsColumnRange[T any](col Column[T]) bool {
+	if col.Heatmap != nil && (col.Heatmap.Min == nil || col.Heatmap.Max == nil) {
+		return true
+	}
+	return col.DataBar != nil && col.DataBar.Max == nil
+}
+
+func columnRanges[T any](data []T, columns []Column[T], getter *fieldGetter, filter func(T) bool) []numericRange {
+	ranges := make([]numericRange, len(columns))
+	needed := false
+	for _, col := range columns {
+		if needsColumnRange(col) {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return ranges
+	}
+	for _, row := range data {
+		if !keepRow(filter, row) {
+			continue
+		}
+		for i, col := range columns {
+			if !needsColumnRange(col) {
+				continue
+			}
+			value, _ := columnValue(getter, row, col)
+			if number, ok := numericValue(value); ok {
+				ranges[i].add(number)
+			}
+		}
+	}
+	return ranges
+}
+
+func heatmapColor(value any, spec *HeatmapSpec, computed numericRange) (string, bool) {
+	number, ok := numericValue(value)
+	if !ok || !isFinite(number) {
+		return "", false
+	}
+	lo, hi := computed.min, computed.max
+	if spec.Min != nil {
+		lo = *spec.Min
+	}
+	if spec.Max != nil {
+		hi = *spec.Max
+	}
+	if !computed.ok && (spec.Min == nil || spec.Max == nil) {
+		return "", false
+	}
+	if !isFinite(lo) || !isFinite(hi) {
+		return "", false
+	}
+	ratio := 0.0
+	if hi > lo {
+		ratio = math.Min(math.Max((number-lo)/(hi-lo), 0), 1)
+	}
+	low := spec.LowColor
+	if low == "" {
+		low = defaultHeatmapLowColor
+	}
+	high := spec.HighColor
+	if high == "" {
+		high = defaultHeatmapHighColor
+	}
+	lr, lg, lb, ok := parseHexColor(low)
+	if !ok {
+		return "", false
+	}
+	hr, hg, hb, ok := parseHexColor(high)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("#%02x%02x%02x",
+		mixChannel(lr, hr, ratio),
+		mixChannel(lg, hg, ratio),
+		mixChannel(lb, hb, ratio),
+	), true
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func mixChannel(from, to uint8, ratio float64) uint8 {
+	return uint8(math.Round(float64(from) + (float64(to)-float64(from))*ratio))
+}
+
+func parseHexColor(color string) (uint8, uint8, uint8, bool) {
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(value >> 16), uint8(value >> 8), uint8(value), true
+}
+
+// cssColorPattern accepts hex colors, rgb()/hsl() functions with plain
+// numeric arguments, and bare keywords such as named colors.
+var cssColorPattern = regexp.MustCompile(`^(#[0-9A-Fa-f]{3,8}|(rgb|rgba|hsl|hsla)\([0-9.,%/ ]+\)|[A-Za-z]+)$`)
+
+// cssColor reports whether a schema-supplied color is safe to emit in a style
+// attribute, returning it trimmed.
+func cssColor(color string) (string, bool) {
+	color = strings.TrimSpace(color)
+	if !cssColorPattern.MatchString(color) {
+		return "", false
+	}
+	return color, true
+}
@@ -0,0 +1,63 @@
+package extable
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Highlight marks occurrences of Term in rendered cell text with
+// <mark class="extable-highlight">.
+type Highlight struct {
+	Term            string
+	CaseInsensitive bool
+}
+
+// text writes escaped cell text, wrapping highlight matches when configured.
+func (r *tableRenderer[T]) text(builder *htmlBuilder, text string) {
+	h := r.opts.Highlight
+	if h == nil || h.Term == "" {
+		builder.text(text)
+		return
+	}
+	last := 0
+	for _, m := range highlightMatches(text, h.Term, h.CaseInsensitive) {
+		builder.text(text[last:m[0]])
+		builder.openTag("mark", "class", "extable-highlight")
+		builder.text(text[m[0]:m[1]])
+		builder.closeTag("mark")
+		last = m[1]
+	}
+	builder.text(text[last:])
+}
+
+func highlightMatches(text string, term string, caseInsensitive bool) [][2]int {
+	var matches [][2]int
+	if !caseInsensitive {
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], term)
+			if i < 0 {
+				return matches
+			}
+			start := offset + i
+			offset = start + len(term)
+			matches = append(matches, [2]int{start, offset})
+		}
+	}
+	// Byte lengths can change under case folding, so compare rune-count windows.
+	termRunes := utf8.RuneCountInString(term)
+	for start := 0; start < len(text); {
+		end := start
+		for n := 0; n < termRunes && end < len(text); n += 1 {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+		}
+		if strings.EqualFold(text[start:end], term) {
+			matches = append(matches, [2]int{start, end})
+			start = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+	}
+	return matches
+}
@@ -0,0 +1,36 @@
+package extable
+
+// SafeHTML is markup that is trusted by the caller and emitted without escaping.
+type SafeHTML string
+
+// SpannedCell, returned from Column.Formula, replaces the cell with trusted
+// markup spanning ColSpan columns from this one, for messages and dividers
+// inside a row. The covered columns are skipped for that row.
+type SpannedCell struct {
+	HTML    SafeHTML
+	ColSpan int
+}
+
+type RowContext struct {
+	RowIndex int
+	Row      any
+	// ColumnCount includes the row header column, for colspan on injected rows.
+	ColumnCount int
+}
+
+type CellContext struct {
+	RowIndex int
+	Row      any
+	ColKey   string
+	Value    any
+}
+
+// Hooks inject caller-provided markup around rows and cells. Row hooks emit
+// siblings of the <tr> (such as expander rows); cell hooks emit inside the <td>
+// around the rendered value.
+type Hooks struct {
+	BeforeRow  func(ctx RowContext) SafeHTML
+	AfterRow   func(ctx RowContext) SafeHTML
+	BeforeCell func(ctx CellContext) SafeHTML
+	AfterCell  func(ctx CellContext) SafeHTML
+}
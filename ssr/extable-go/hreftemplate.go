@@ -0,0 +1,112 @@
+package extable
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// hrefTemplate is a parsed href with {field} placeholders. Placeholders in
+// the path are path-escaped and those after "?" are query-escaped; "{{" and
+// "}}" produce literal braces.
+type hrefTemplate struct {
+	parts []hrefPart
+}
+
+type hrefPart struct {
+	literal string
+	field   string
+	query   bool
+}
+
+func parseHrefTemplate(template string) (*hrefTemplate, error) {
+	t := &hrefTemplate{}
+	var literal strings.Builder
+	query := false
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case c == '{' && strings.HasPrefix(template[i:], "{{"):
+			literal.WriteByte('{')
+			i++
+		case c == '}' && strings.HasPrefix(template[i:], "}}"):
+			literal.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed placeholder at offset %d", i)
+			}
+			field := template[i+1 : i+end]
+			if field == "" {
+				return nil, fmt.Errorf("empty placeholder at offset %d", i)
+			}
+			t.parts = append(t.parts, hrefPart{literal: literal.String()}, hrefPart{field: field, query: query})
+			literal.Reset()
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("unexpected '}' at offset %d", i)
+		default:
+			if c == '?' {
+				query = true
+			}
+			literal.WriteByte(c)
+		}
+	}
+	t.parts = append(t.parts, hrefPart{literal: literal.String()})
+	return t, nil
+}
+
+// expand resolves placeholders against row; missing fields expand to "".
+func (t *hrefTemplate) expand(getter *fieldGetter, row any) string {
+	var sb strings.Builder
+	for _, part := range t.parts {
+		if part.field == "" {
+			sb.WriteString(part.literal)
+			continue
+		}
+		value, _ := getter.valueForKey(row, part.field)
+		if value == nil {
+			continue
+		}
+		text := stringifyValue(value)
+		if part.query {
+			sb.WriteString(url.QueryEscape(text))
+		} else {
+			sb.WriteString(url.PathEscape(text))
+		}
+	}
+	return sb.String()
+}
+
+func compileHrefTemplates[T any](columns []Column[T]) ([]*hrefTemplate, error) {
+	templates := make([]*hrefTemplate, len(columns))
+	for i, col := range columns {
+		source := ""
+		switch {
+		case col.Type == ColumnTypeLink && col.Link != nil:
+			source = col.Link.Href
+		case col.Type == ColumnTypeButton && col.Button != nil:
+			source = col.Button.Href
+		}
+		if source == "" {
+			continue
+		}
+		t, err := parseHrefTemplate(source)
+		if err != nil {
+			return nil, &SchemaError{ColKey: col.Key, Message: "href template", Err: err}
+		}
+		templates[i] = t
+	}
+	return templates, nil
+}
+
+// expandHref expands tmpl and applies the URL policy, warning on rejection.
+func (r *tableRenderer[T]) expandHref(rowIndex int, colKey string, tmpl *hrefTemplate, row T) (string, bool) {
+	href, allowed := r.opts.urlPolicy().sanitize(tmpl.expand(r.getter, row))
+	if !allowed {
+		r.warn(rowIndex, colKey, "button href rejected by url policy")
+		return "", false
+	}
+	return href, true
+}
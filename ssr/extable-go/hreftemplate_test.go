@@ -0,0 +1,44 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+type orderRow struct {
+	OrderID string `json:"order_id"`
+	Status  string `json:"status"`
+	Label   string `json:"label"`
+}
+
+func TestHrefTemplates(t *testing.T) {
+	schema := Schema[orderRow]{Columns: []Column[orderRow]{
+		{Key: "label", Type: ColumnTypeLink, Link: &LinkSpec{Href: "/orders/{order_id}?tab={status}"}},
+		{Key: "status", Type: ColumnTypeButton, Button: &ButtonSpec{Href: "/orders/{order_id}/retry"}},
+	}}
+	result, err := RenderTableHTML([]orderRow{{OrderID: "a/1", Status: "on hold&x", Label: "Order 1"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<a class="extable-action-link" href="/orders/a%2F1?tab=on+hold%26x">Order 1</a>`) {
+		t.Fatalf("expected expanded link href, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `data-href="/orders/a%2F1/retry"`) {
+		t.Fatalf("expected expanded button href, got %s", result.HTML)
+	}
+}
+
+func TestHrefTemplateErrors(t *testing.T) {
+	for _, template := range []string{"/orders/{id", "/orders/{}", "/orders/}"} {
+		if _, err := parseHrefTemplate(template); err == nil {
+			t.Errorf("expected error for %q", template)
+		}
+	}
+	tmpl, err := parseHrefTemplate("/x/{{literal}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := tmpl.expand(nil, nil); got != "/x/{literal}" {
+		t.Fatalf("unexpected literal braces: %q", got)
+	}
+}
@@ -1,30 +1,176 @@
 package extable
 
-import "strings"
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
 
 type htmlBuilder struct {
-	sb strings.Builder
+	sb     strings.Builder
+	indent string
+	stack  []openElement
+	inline int
+	// attrProblems collects attributes openTag refused to write.
+	attrProblems []string
+	// classPrefix, when set, replaces the extable- prefix in class attributes.
+	classPrefix string
+}
+
+type openElement struct {
+	tag           string
+	inline        bool
+	hasBlockChild bool
+}
+
+// inlineTags stay on the same line as their surrounding text when indenting,
+// so pretty-printing never adds whitespace inside a phrase.
+var inlineTags = map[string]bool{
+	"a":      true,
+	"abbr":   true,
+	"b":      true,
+	"button": true,
+	"code":   true,
+	"em":     true,
+	"i":      true,
+	"img":    true,
+	"input":  true,
+	"label":  true,
+	"mark":   true,
+	"option": true,
+	"select": true,
+	"small":  true,
+	"span":   true,
+	"strong": true,
+	"svg":    true,
+	// Whitespace inside a textarea is part of its value.
+	"textarea": true,
+}
+
+func newHTMLBuilder(indent string, depth int) *htmlBuilder {
+	b := &htmlBuilder{indent: indent}
+	for i := 0; i < depth; i += 1 {
+		b.stack = append(b.stack, openElement{})
+	}
+	return b
 }
 
 func (b *htmlBuilder) openTag(tag string, attrs ...string) {
+	inline := b.inline > 0 || inlineTags[tag]
+	b.startTag(tag, inline, attrs)
+	b.stack = append(b.stack, openElement{tag: tag, inline: inline})
+	if inline {
+		b.inline += 1
+	}
+}
+
+// voidTag writes an element that has no content or closing tag, such as <img>.
+func (b *htmlBuilder) voidTag(tag string, attrs ...string) {
+	b.startTag(tag, b.inline > 0 || inlineTags[tag], attrs)
+}
+
+func (b *htmlBuilder) startTag(tag string, inline bool, attrs []string) {
+	if b.indent != "" && !inline {
+		b.markBlockChild()
+		b.newline(len(b.stack))
+	}
 	b.sb.WriteString("<")
 	b.sb.WriteString(tag)
+	var seen map[string]bool
+	written := make([]string, 0, len(attrs))
 	for i := 0; i+1 < len(attrs); i += 2 {
-		key := attrs[i]
+		key := strings.TrimSpace(attrs[i])
 		value := attrs[i+1]
-		if key == "" {
+		if attrs[i] == "" {
+			continue
+		}
+		if !validAttrName(key) {
+			b.attrProblems = append(b.attrProblems, fmt.Sprintf("attribute %q on <%s> dropped: invalid name", attrs[i], tag))
+			continue
+		}
+		if seen == nil {
+			seen = make(map[string]bool, len(attrs)/2)
+		}
+		folded := strings.ToLower(key)
+		if seen[folded] {
+			b.attrProblems = append(b.attrProblems, fmt.Sprintf("attribute %q on <%s> dropped: duplicate name", key, tag))
 			continue
 		}
+		seen[folded] = true
+		if folded == "class" && b.classPrefix != "" {
+			value = prefixClasses(value, b.classPrefix)
+		}
+		written = append(written, key, value)
+	}
+	sortDataAttrs(written)
+	for i := 0; i < len(written); i += 2 {
 		b.sb.WriteString(" ")
-		b.sb.WriteString(key)
+		b.sb.WriteString(written[i])
 		b.sb.WriteString("=\"")
-		b.sb.WriteString(escapeHTML(value))
+		b.sb.WriteString(escapeAttr(written[i+1]))
 		b.sb.WriteString("\"")
 	}
 	b.sb.WriteString(">")
 }
 
+// sortDataAttrs orders data-* attributes by name within the positions they
+// already occupy, so other attributes keep their place and the order of
+// data attributes never depends on the code path that added them.
+func sortDataAttrs(attrs []string) {
+	var slots []int
+	for i := 0; i < len(attrs); i += 2 {
+		if strings.HasPrefix(attrs[i], "data-") {
+			slots = append(slots, i)
+		}
+	}
+	if len(slots) < 2 {
+		return
+	}
+	pairs := make([][2]string, len(slots))
+	for j, i := range slots {
+		pairs[j] = [2]string{attrs[i], attrs[i+1]}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a][0] < pairs[b][0] })
+	for j, i := range slots {
+		attrs[i], attrs[i+1] = pairs[j][0], pairs[j][1]
+	}
+}
+
+func prefixClasses(classes, prefix string) string {
+	names := strings.Fields(classes)
+	for i, name := range names {
+		if rest, ok := strings.CutPrefix(name, "extable-"); ok {
+			names[i] = prefix + rest
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// joinClasses joins class names in the order given, dropping empty names and
+// repeats, so the class attribute is stable whatever combination of features
+// contributed to it.
+func joinClasses(classes []string) string {
+	out := make([]string, 0, len(classes))
+	for _, class := range classes {
+		if class == "" || slices.Contains(out, class) {
+			continue
+		}
+		out = append(out, class)
+	}
+	return strings.Join(out, " ")
+}
+
 func (b *htmlBuilder) closeTag(tag string) {
+	if n := len(b.stack); n > 0 {
+		top := b.stack[n-1]
+		b.stack = b.stack[:n-1]
+		if top.inline {
+			b.inline -= 1
+		} else if b.indent != "" && top.hasBlockChild {
+			b.newline(len(b.stack))
+		}
+	}
 	b.sb.WriteString("</")
 	b.sb.WriteString(tag)
 	b.sb.WriteString(">")
@@ -38,6 +184,30 @@ func (b *htmlBuilder) raw(html string) {
 	b.sb.WriteString(html)
 }
 
+// rawBlock inserts a pre-rendered block-level fragment, placing it on its own
+// line when indenting.
+func (b *htmlBuilder) rawBlock(html string) {
+	if b.indent != "" && b.inline == 0 {
+		b.markBlockChild()
+		b.newline(len(b.stack))
+	}
+	b.sb.WriteString(html)
+}
+
+func (b *htmlBuilder) markBlockChild() {
+	if n := len(b.stack); n > 0 {
+		b.stack[n-1].hasBlockChild = true
+	}
+}
+
+func (b *htmlBuilder) newline(depth int) {
+	if b.sb.Len() == 0 {
+		return
+	}
+	b.sb.WriteString("\n")
+	b.sb.WriteString(strings.Repeat(b.indent, depth))
+}
+
 func (b *htmlBuilder) string() string {
 	return b.sb.String()
 }
@@ -0,0 +1,71 @@
+package extable
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var wellFormedSpan = regexp.MustCompile(`^<span(?: [^\s"'<>/=` + "`" + `\x00-\x1f\x7f-\x9f]+="[^"<>]*")*></span>$`)
+
+func TestAttributeEscaping(t *testing.T) {
+	b := newHTMLBuilder("", 0)
+	b.openTag("span", `on"x y=1`, "a", "title", "a\"b'c&d\x01e")
+	b.closeTag("span")
+	expected := "<span title=\"a&quot;b&#39;c&amp;d\uFFFDe\"></span>"
+	if b.sb.String() != expected {
+		t.Fatalf("unexpected attribute output %q", b.sb.String())
+	}
+	if len(b.attrProblems) != 1 || !strings.Contains(b.attrProblems[0], "invalid name") {
+		t.Fatalf("expected invalid name problem, got %v", b.attrProblems)
+	}
+}
+
+func TestAttributeDuplicates(t *testing.T) {
+	b := newHTMLBuilder("", 0)
+	b.openTag("td", "class", "a", " data-x ", "1", "CLASS", "b")
+	b.closeTag("td")
+	if b.sb.String() != `<td class="a" data-x="1"></td>` {
+		t.Fatalf("unexpected attribute output %q", b.sb.String())
+	}
+	if len(b.attrProblems) != 1 || !strings.Contains(b.attrProblems[0], `"CLASS" on <td> dropped: duplicate`) {
+		t.Fatalf("expected duplicate problem, got %v", b.attrProblems)
+	}
+}
+
+func FuzzAttributeEscaping(f *testing.F) {
+	f.Add("data-x", "value")
+	f.Add(`x" onload="alert(1)`, `"><script>`)
+	f.Add("a b", "\x00\x7f\u0085")
+	f.Add("\xff", "\xfe")
+	f.Fuzz(func(t *testing.T, key, value string) {
+		b := newHTMLBuilder("", 0)
+		b.openTag("span", key, value)
+		b.closeTag("span")
+		out := b.sb.String()
+		if !wellFormedSpan.MatchString(out) {
+			t.Fatalf("malformed output %q for key %q value %q", out, key, value)
+		}
+		if name := strings.TrimSpace(key); validAttrName(name) && utf8.ValidString(value) && !strings.ContainsFunc(value, isControlRune) {
+			start := len("<span ") + len(name) + len(`="`)
+			got := html.UnescapeString(out[start : len(out)-len(`"></span>`)])
+			if got != value {
+				t.Fatalf("value did not round-trip: %q != %q", got, value)
+			}
+		}
+	})
+}
+
+func TestDataAttributesSorted(t *testing.T) {
+	b := newHTMLBuilder("", 0)
+	b.openTag("td", "class", "x y x", "data-z", "1", "title", "t", "data-a", "2")
+	b.closeTag("td")
+	if b.sb.String() != `<td class="x y x" data-a="2" title="t" data-z="1"></td>` {
+		t.Fatalf("unexpected attribute order %q", b.sb.String())
+	}
+	if got := joinClasses([]string{"a", "", "b", "a"}); got != "a b" {
+		t.Fatalf("unexpected classes %q", got)
+	}
+}
@@ -0,0 +1,136 @@
+package extable
+
+import (
+	"regexp"
+	"strings"
+)
+
+type RowDiffOp string
+
+const (
+	RowInserted RowDiffOp = "insert"
+	RowRemoved  RowDiffOp = "remove"
+	RowChanged  RowDiffOp = "change"
+)
+
+// RowDiff is one body row that differs between two renders. OldIndex and
+// NewIndex are positions among the <tbody> rows of each result, -1 where the
+// row does not exist. HTML is the new <tr> and is empty for removals.
+type RowDiff struct {
+	Op       RowDiffOp
+	OldIndex int
+	NewIndex int
+	HTML     string
+}
+
+var rowHeaderCellPattern = regexp.MustCompile(`(?s)<th class="extable-row-header[^"]*"[^>]*>.*?</th>`)
+
+// DiffHTML compares the body rows of two rendered tables and returns the
+// minimal insert/remove edits, with a removal and insertion at the same spot
+// reported as a change, in new-row order. Row-number header cells are ignored
+// when matching, so inserting a row does not mark every later row changed.
+func DiffHTML(old, new Result) []RowDiff {
+	oldRows, newRows := bodyRows(old.HTML), bodyRows(new.HTML)
+	oldKeys, newKeys := rowMatchKeys(oldRows), rowMatchKeys(newRows)
+
+	// Longest common subsequence over the rows between the common prefix and suffix.
+	prefix := 0
+	for prefix < len(oldKeys) && prefix < len(newKeys) && oldKeys[prefix] == newKeys[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldKeys)-prefix && suffix < len(newKeys)-prefix &&
+		oldKeys[len(oldKeys)-1-suffix] == newKeys[len(newKeys)-1-suffix] {
+		suffix++
+	}
+	a, b := oldKeys[prefix:len(oldKeys)-suffix], newKeys[prefix:len(newKeys)-suffix]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diffs := make([]RowDiff, 0)
+	var removed, inserted []int
+	flush := func() {
+		paired := min(len(removed), len(inserted))
+		for k := 0; k < paired; k++ {
+			diffs = append(diffs, RowDiff{Op: RowChanged, OldIndex: removed[k], NewIndex: inserted[k], HTML: newRows[inserted[k]]})
+		}
+		for _, i := range removed[paired:] {
+			diffs = append(diffs, RowDiff{Op: RowRemoved, OldIndex: i, NewIndex: -1})
+		}
+		for _, j := range inserted[paired:] {
+			diffs = append(diffs, RowDiff{Op: RowInserted, OldIndex: -1, NewIndex: j, HTML: newRows[j]})
+		}
+		removed, inserted = removed[:0], inserted[:0]
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			inserted = append(inserted, prefix+j)
+			j++
+		default:
+			removed = append(removed, prefix+i)
+			i++
+		}
+	}
+	flush()
+	return diffs
+}
+
+func rowMatchKeys(rows []string) []string {
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		keys[i] = rowHeaderCellPattern.ReplaceAllString(row, "")
+	}
+	return keys
+}
+
+// bodyRows splits the first <tbody> of html into its top-level <tr> elements,
+// skipping rows of tables nested inside cells.
+func bodyRows(html string) []string {
+	start := strings.Index(html, "<tbody")
+	if start < 0 {
+		return nil
+	}
+	rows := make([]string, 0)
+	depth, rowStart := 0, -1
+	for pos := start + len("<tbody"); pos < len(html); {
+		next := strings.IndexByte(html[pos:], '<')
+		if next < 0 {
+			break
+		}
+		pos += next
+		rest := html[pos:]
+		switch {
+		case strings.HasPrefix(rest, "<tbody") || strings.HasPrefix(rest, "<table"):
+			depth++
+		case strings.HasPrefix(rest, "</tbody>") || strings.HasPrefix(rest, "</table>"):
+			if depth == 0 {
+				return rows
+			}
+			depth--
+		case depth == 0 && (strings.HasPrefix(rest, "<tr>") || strings.HasPrefix(rest, "<tr ")):
+			rowStart = pos
+		case depth == 0 && strings.HasPrefix(rest, "</tr>") && rowStart >= 0:
+			rows = append(rows, strings.TrimSpace(html[rowStart:pos+len("</tr>")]))
+			rowStart = -1
+		}
+		pos++
+	}
+	return rows
+}
@@ -0,0 +1,39 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffHTML(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	render := func(rows []sampleRow) Result {
+		result, err := RenderTableHTML(rows, schema, Options{})
+		if err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		return result
+	}
+	old := render([]sampleRow{{Name: "a", Age: 1}, {Name: "b", Age: 2}, {Name: "c", Age: 3}, {Name: "d", Age: 4}})
+	new := render([]sampleRow{{Name: "x", Age: 0}, {Name: "a", Age: 1}, {Name: "b", Age: 20}, {Name: "d", Age: 4}})
+
+	diffs := DiffHTML(old, new)
+	if len(diffs) != 3 {
+		t.Fatalf("expected three diffs, got %+v", diffs)
+	}
+	if diffs[0].Op != RowInserted || diffs[0].NewIndex != 0 || !strings.Contains(diffs[0].HTML, ">x</td>") {
+		t.Fatalf("expected insert at 0, got %+v", diffs[0])
+	}
+	if diffs[1].Op != RowChanged || diffs[1].OldIndex != 1 || diffs[1].NewIndex != 2 || !strings.Contains(diffs[1].HTML, ">20</td>") {
+		t.Fatalf("expected change of b, got %+v", diffs[1])
+	}
+	if diffs[2].Op != RowRemoved || diffs[2].OldIndex != 2 || diffs[2].HTML != "" {
+		t.Fatalf("expected removal of c, got %+v", diffs[2])
+	}
+	if len(DiffHTML(new, new)) != 0 {
+		t.Fatalf("expected no diffs for identical renders")
+	}
+}
@@ -0,0 +1,184 @@
+package extable
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InferOptions tunes InferSchema. Zero values select the defaults.
+type InferOptions struct {
+	// SampleSize caps how many rows are inspected; 100 when zero, all rows when negative.
+	SampleSize int
+	// EnumMaxValues is the largest number of distinct strings still treated as an enum; 10 when zero, never when negative.
+	EnumMaxValues int
+}
+
+type inferKind int
+
+const (
+	inferNone inferKind = iota
+	inferBoolean
+	inferInt
+	inferNumber
+	inferDate
+	inferTime
+	inferDateTime
+	inferTags
+	inferString
+)
+
+var inferColumnTypes = map[inferKind]ColumnType{
+	inferNone:     ColumnTypeString,
+	inferBoolean:  ColumnTypeBoolean,
+	inferInt:      ColumnTypeInt,
+	inferNumber:   ColumnTypeNumber,
+	inferDate:     ColumnTypeDate,
+	inferTime:     ColumnTypeTime,
+	inferDateTime: ColumnTypeDateTime,
+	inferTags:     ColumnTypeTags,
+	inferString:   ColumnTypeString,
+}
+
+// InferSchema guesses a column per key from sampled rows, for exploratory
+// rendering of arbitrary JSON. Columns are ordered by key. A column whose
+// values disagree falls back to string, except that integers and decimals
+// combine to number; strings with few distinct values become an enum.
+func InferSchema(rows []map[string]any, opts InferOptions) Schema[map[string]any] {
+	sample := rows
+	size := opts.SampleSize
+	if size == 0 {
+		size = 100
+	}
+	if size > 0 && len(sample) > size {
+		sample = sample[:size]
+	}
+	enumMax := opts.EnumMaxValues
+	if enumMax == 0 {
+		enumMax = 10
+	}
+
+	kinds := make(map[string]inferKind)
+	distinct := make(map[string]map[string]bool)
+	counts := make(map[string]int)
+	textual := make(map[string]bool)
+	for _, row := range sample {
+		for key, value := range row {
+			if _, seen := kinds[key]; !seen {
+				kinds[key] = inferNone
+				distinct[key] = make(map[string]bool)
+			}
+			kind := inferValueKind(value)
+			if kind == inferNone {
+				continue
+			}
+			counts[key]++
+			kinds[key] = mergeInferKinds(kinds[key], kind)
+			if text, ok := value.(string); ok {
+				textual[key] = true
+				if len(distinct[key]) <= enumMax {
+					distinct[key][text] = true
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(kinds))
+	for key := range kinds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	schema := Schema[map[string]any]{Columns: make([]Column[map[string]any], 0, len(keys))}
+	for _, key := range keys {
+		col := Column[map[string]any]{Key: key, Type: inferColumnTypes[kinds[key]]}
+		if kinds[key] == inferBoolean && textual[key] {
+			// Boolean columns only read strings through BooleanTruthy.
+			col.Format = &Format{BooleanTruthy: []string{"true"}}
+		}
+		// An enum needs repetition: every value should appear at least twice on average.
+		if values := distinct[key]; kinds[key] == inferString && enumMax > 0 && len(values) <= enumMax && counts[key] >= 2*len(values) {
+			col.Type = ColumnTypeEnum
+			col.Enum = &EnumSpec{Values: make([]string, 0, len(values))}
+			for value := range values {
+				col.Enum.Values = append(col.Enum.Values, value)
+			}
+			sort.Strings(col.Enum.Values)
+		}
+		schema.Columns = append(schema.Columns, col)
+	}
+	return schema
+}
+
+func inferValueKind(value any) inferKind {
+	switch v := value.(type) {
+	case nil:
+		return inferNone
+	case bool:
+		return inferBoolean
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return inferInt
+	case float32, float64:
+		number, _ := numericValue(v)
+		if number == math.Trunc(number) && !math.IsInf(number, 0) {
+			return inferInt
+		}
+		return inferNumber
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return inferInt
+		}
+		return inferNumber
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return inferDate
+		}
+		return inferDateTime
+	case []string, []any:
+		return inferTags
+	case string:
+		return inferStringKind(strings.TrimSpace(v))
+	}
+	return inferString
+}
+
+func inferStringKind(text string) inferKind {
+	if text == "" {
+		return inferNone
+	}
+	if strings.EqualFold(text, "true") || strings.EqualFold(text, "false") {
+		return inferBoolean
+	}
+	if _, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return inferInt
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return inferNumber
+	}
+	if _, err := time.Parse("2006-01-02", text); err == nil {
+		return inferDate
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if _, err := time.Parse(layout, text); err == nil {
+			return inferTime
+		}
+	}
+	if _, ok := parseTimeString(text, nil); ok {
+		return inferDateTime
+	}
+	return inferString
+}
+
+func mergeInferKinds(a, b inferKind) inferKind {
+	switch {
+	case a == inferNone || a == b:
+		return b
+	case (a == inferInt && b == inferNumber) || (a == inferNumber && b == inferInt):
+		return inferNumber
+	case (a == inferDate && b == inferDateTime) || (a == inferDateTime && b == inferDate):
+		return inferDateTime
+	}
+	return inferString
+}
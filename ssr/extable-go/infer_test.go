@@ -0,0 +1,47 @@
+package extable
+
+import "testing"
+
+func TestInferSchema(t *testing.T) {
+	rows := []map[string]any{
+		{"id": 1.0, "price": 1.5, "active": true, "day": "2024-01-02", "status": "open", "note": "a", "at": "2024-01-02T10:00:00Z"},
+		{"id": 2.0, "price": 2, "active": false, "day": "2024-01-03", "status": "closed", "note": "b"},
+		{"id": 3.0, "price": "3", "active": "true", "day": nil, "status": "open", "note": "c", "at": "2024-01-02"},
+		{"id": 4.0, "price": 4, "active": nil, "day": "2024-01-05", "status": "open", "note": 5},
+	}
+	schema := InferSchema(rows, InferOptions{})
+	want := map[string]ColumnType{
+		"active": ColumnTypeBoolean,
+		"at":     ColumnTypeDateTime,
+		"day":    ColumnTypeDate,
+		"id":     ColumnTypeInt,
+		"note":   ColumnTypeString,
+		"price":  ColumnTypeNumber,
+		"status": ColumnTypeEnum,
+	}
+	if len(schema.Columns) != len(want) || schema.Columns[0].Key != "active" {
+		t.Fatalf("unexpected columns %+v", schema.Columns)
+	}
+	for _, col := range schema.Columns {
+		if col.Type != want[col.Key] {
+			t.Fatalf("column %s: expected %s, got %s", col.Key, want[col.Key], col.Type)
+		}
+	}
+	if schema.Columns[0].Format == nil || len(schema.Columns[0].Format.BooleanTruthy) != 1 {
+		t.Fatalf("expected truthy strings for a boolean column holding text")
+	}
+	status := schema.Columns[len(schema.Columns)-1]
+	if status.Enum == nil || len(status.Enum.Values) != 2 || status.Enum.Values[0] != "closed" {
+		t.Fatalf("unexpected enum spec %+v", status.Enum)
+	}
+
+	limited := InferSchema(rows, InferOptions{SampleSize: 1, EnumMaxValues: -1})
+	for _, col := range limited.Columns {
+		if col.Key == "price" && col.Type != ColumnTypeNumber {
+			t.Fatalf("expected the first row alone to decide price, got %s", col.Type)
+		}
+		if col.Type == ColumnTypeEnum {
+			t.Fatalf("expected enums to be disabled")
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package extable
+
+import (
+	"encoding/hex"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ipValue reads addresses and prefixes as a netip.Prefix; a single address
+// is a full-length prefix. IPv4-mapped IPv6 addresses are unmapped.
+func ipValue(value any) (netip.Prefix, bool) {
+	switch v := value.(type) {
+	case netip.Addr:
+		if v.IsValid() {
+			v = v.Unmap()
+			return netip.PrefixFrom(v, v.BitLen()), true
+		}
+	case netip.Prefix:
+		if v.IsValid() {
+			return v, true
+		}
+	case net.IP:
+		if addr, ok := netip.AddrFromSlice(v); ok {
+			addr = addr.Unmap()
+			return netip.PrefixFrom(addr, addr.BitLen()), true
+		}
+	case *net.IPNet:
+		if v != nil {
+			if addr, ok := netip.AddrFromSlice(v.IP); ok {
+				ones, _ := v.Mask.Size()
+				return netip.PrefixFrom(addr.Unmap(), ones), true
+			}
+		}
+	case string:
+		text := strings.TrimSpace(v)
+		if strings.Contains(text, "/") {
+			if prefix, err := netip.ParsePrefix(text); err == nil {
+				return prefix, true
+			}
+			return netip.Prefix{}, false
+		}
+		if addr, err := netip.ParseAddr(text); err == nil {
+			addr = addr.Unmap()
+			return netip.PrefixFrom(addr, addr.BitLen()), true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+func formatIP(prefix netip.Prefix) string {
+	if prefix.Bits() == prefix.Addr().BitLen() {
+		return prefix.Addr().String()
+	}
+	return prefix.String()
+}
+
+// ipSortKey is the address as 16-byte hex, followed for prefixes by "/" and
+// the zero-padded length. IPv4 uses its ::ffff:0:0/96 mapped form, so it sorts
+// after low IPv6 addresses such as ::1 and before global ones such as 2001::.
+func ipSortKey(prefix netip.Prefix) string {
+	bytes := prefix.Addr().As16()
+	key := hex.EncodeToString(bytes[:])
+	if prefix.Bits() != prefix.Addr().BitLen() {
+		bits := strconv.Itoa(prefix.Bits())
+		key += "/" + strings.Repeat("0", 3-len(bits)) + bits
+	}
+	return key
+}
@@ -0,0 +1,55 @@
+package extable
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestIPValue(t *testing.T) {
+	cases := []struct {
+		value any
+		text  string
+		key   string
+	}{
+		{netip.MustParseAddr("10.0.0.2"), "10.0.0.2", "00000000000000000000ffff0a000002"},
+		{"::ffff:10.0.0.2", "10.0.0.2", "00000000000000000000ffff0a000002"},
+		{net.ParseIP("2001:db8::1"), "2001:db8::1", "20010db8000000000000000000000001"},
+		{netip.MustParsePrefix("192.168.0.0/24"), "192.168.0.0/24", "00000000000000000000ffffc0a80000/024"},
+	}
+	for _, c := range cases {
+		prefix, ok := ipValue(c.value)
+		if !ok {
+			t.Fatalf("%v: not recognized", c.value)
+		}
+		if got := formatIP(prefix); got != c.text {
+			t.Fatalf("%v: got text %q, want %q", c.value, got, c.text)
+		}
+		if got := ipSortKey(prefix); got != c.key {
+			t.Fatalf("%v: got key %q, want %q", c.value, got, c.key)
+		}
+	}
+	if _, ok := ipValue("10.0.0.300"); ok {
+		t.Fatalf("expected invalid address to be rejected")
+	}
+}
+
+func TestIPColumnSort(t *testing.T) {
+	type host struct {
+		Addr string `json:"addr"`
+	}
+	schema := Schema[host]{Columns: []Column[host]{{Key: "addr", Type: ColumnTypeIP}}}
+	rows := []host{{Addr: "10.0.0.10"}, {Addr: "10.0.0.9"}, {Addr: "2001:db8::1"}}
+	result, err := RenderTableHTML(rows, schema, Options{Sort: []SortSpec{{Key: "addr"}}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	if strings.Index(html, ">10.0.0.9<") > strings.Index(html, ">10.0.0.10<") {
+		t.Fatalf("expected numeric ordering: %s", html)
+	}
+	if !strings.Contains(html, `data-value="00000000000000000000ffff0a000009">10.0.0.9</td>`) {
+		t.Fatalf("missing sort key: %s", html)
+	}
+}
@@ -0,0 +1,17 @@
+package extable
+
+import "strconv"
+
+// navAttrs returns the roving-tabindex attributes for a body cell. Coordinates
+// count visible rendered rows, so the first visible cell is always the tab
+// stop; rows of collapsed groups are left out of navigation.
+func (r *tableRenderer[T]) navAttrs(colIndex int) []string {
+	if !r.opts.KeyboardNav || r.rowHidden() {
+		return nil
+	}
+	tabindex := "-1"
+	if r.position == 0 && colIndex == 0 {
+		tabindex = "0"
+	}
+	return []string{"tabindex", tabindex, "data-nav", strconv.Itoa(r.position) + "," + strconv.Itoa(colIndex)}
+}
@@ -0,0 +1,229 @@
+package extable
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadCSV reads a CSV file whose first record is a header row and returns
+// one map per record, keyed by column key. Header cells match a column by Key
+// or Header; unmatched CSV columns are ignored. Each cell is converted to its
+// column's type; cells that cannot be converted keep their text and produce a
+// warning, so uploads can be rendered and reviewed as-is.
+func LoadCSV(r io.Reader, schema Schema[map[string]any]) ([]map[string]any, []Warning, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []map[string]any{}, []Warning{}, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("ssr: read csv header: %w", err)
+	}
+	positions := make([]*Column[map[string]any], len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		for c := range schema.Columns {
+			col := &schema.Columns[c]
+			if col.Key == name || (col.Header != "" && col.Header == name) {
+				positions[i] = col
+				break
+			}
+		}
+	}
+	rows := make([]map[string]any, 0)
+	warnings := make([]Warning, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("ssr: read csv row %d: %w", len(rows)+1, err)
+		}
+		row := make(map[string]any, len(schema.Columns))
+		for i, text := range record {
+			if i >= len(positions) || positions[i] == nil {
+				continue
+			}
+			col := positions[i]
+			value, ok := coerceText(text, *col)
+			if !ok {
+				warnings = append(warnings, coercionWarning(len(rows), *col, text))
+			}
+			row[col.Key] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, warnings, nil
+}
+
+// LoadJSONLines reads one JSON object per line and converts values of known
+// columns like LoadCSV does. Int and uint columns read numbers exactly,
+// number columns as float64; strings are parsed according to the column type
+// and arrays become []string for tags and enumset or []float64 for
+// sparklines. Other keys are kept unchanged.
+func LoadJSONLines(r io.Reader, schema Schema[map[string]any]) ([]map[string]any, []Warning, error) {
+	columns := make(map[string]Column[map[string]any], len(schema.Columns))
+	for _, col := range schema.Columns {
+		columns[col.Key] = col
+	}
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	rows := make([]map[string]any, 0)
+	warnings := make([]Warning, 0)
+	for {
+		var row map[string]any
+		err := decoder.Decode(&row)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("ssr: read json line %d: %w", len(rows)+1, err)
+		}
+		for key, raw := range row {
+			col, known := columns[key]
+			var text string
+			switch v := raw.(type) {
+			case json.Number:
+				text = v.String()
+			case string:
+				text = v
+			case []any:
+				if !known {
+					continue
+				}
+				value, ok := coerceList(v, col)
+				if !ok {
+					warnings = append(warnings, coercionWarning(len(rows), col, fmt.Sprint(v)))
+				}
+				row[key] = value
+				continue
+			default:
+				continue
+			}
+			if !known {
+				if n, ok := raw.(json.Number); ok {
+					row[key], _ = n.Float64()
+				}
+				continue
+			}
+			value, ok := coerceText(text, col)
+			if !ok {
+				warnings = append(warnings, coercionWarning(len(rows), col, text))
+			}
+			row[key] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, warnings, nil
+}
+
+func coercionWarning[T any](rowIndex int, col Column[T], text string) Warning {
+	return Warning{RowIndex: rowIndex, ColKey: col.Key, Message: fmt.Sprintf("cannot read %q as %s", text, col.Type)}
+}
+
+// coerceText converts text to the value a column of col.Type renders
+// natively. Blank text becomes nil. On failure it returns the text unchanged
+// and false.
+func coerceText[T any](text string, col Column[T]) (any, bool) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return nil, true
+	}
+	switch col.Type {
+	case ColumnTypeNumber:
+		if n, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return n, true
+		}
+	case ColumnTypeInt:
+		if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return n, true
+		}
+	case ColumnTypeUint:
+		if n, err := strconv.ParseUint(trimmed, 10, 64); err == nil {
+			return n, true
+		}
+	case ColumnTypeBoolean:
+		if b, ok := booleanValue(trimmed, col.Format); ok {
+			return b, true
+		}
+		if b, err := strconv.ParseBool(trimmed); err == nil {
+			return b, true
+		}
+	case ColumnTypeDate, ColumnTypeTime, ColumnTypeDateTime, ColumnTypePeriod:
+		if t, ok := parseTimeString(trimmed, col.Format); ok {
+			return t, true
+		}
+		if col.Type == ColumnTypePeriod {
+			if t, ok := parsePeriod(trimmed); ok {
+				return t, true
+			}
+		}
+	case ColumnTypeTags, ColumnTypeEnumSet:
+		return splitTags(trimmed, col), true
+	case ColumnTypeSparkline:
+		fields := strings.FieldsFunc(trimmed, func(r rune) bool { return r == ',' || r == ' ' || r == ';' })
+		values := make([]float64, 0, len(fields))
+		for _, field := range fields {
+			n, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return text, false
+			}
+			values = append(values, n)
+		}
+		return values, true
+	default:
+		return text, true
+	}
+	return text, false
+}
+
+// splitTags splits text on the column's tag separator, dropping blank items.
+func splitTags[T any](text string, col Column[T]) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(text, strings.TrimSpace(tagsSeparator(col))) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// coerceList converts a JSON array for list-valued columns. Other columns,
+// and arrays with items of the wrong kind, keep the array and report false.
+func coerceList[T any](items []any, col Column[T]) (any, bool) {
+	switch col.Type {
+	case ColumnTypeTags, ColumnTypeEnumSet:
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			text, ok := item.(string)
+			if !ok {
+				return items, false
+			}
+			values = append(values, text)
+		}
+		return values, true
+	case ColumnTypeSparkline:
+		values := make([]float64, 0, len(items))
+		for _, item := range items {
+			n, ok := item.(json.Number)
+			if !ok {
+				return items, false
+			}
+			f, err := n.Float64()
+			if err != nil {
+				return items, false
+			}
+			values = append(values, f)
+		}
+		return values, true
+	default:
+		return items, false
+	}
+}
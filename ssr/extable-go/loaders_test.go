@@ -0,0 +1,93 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var loaderSchema = Schema[map[string]any]{Columns: []Column[map[string]any]{
+	{Key: "name", Type: ColumnTypeString, Header: "Name"},
+	{Key: "age", Type: ColumnTypeInt},
+	{Key: "active", Type: ColumnTypeBoolean},
+	{Key: "joined", Type: ColumnTypeDate},
+}}
+
+func TestLoadCSV(t *testing.T) {
+	input := "Name,age,active,joined,extra\nAlice,30,true,2024-01-02,x\nBob,old,no,,y\n"
+	rows, warnings, err := LoadCSV(strings.NewReader(input), loaderSchema)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected two rows, got %v", rows)
+	}
+	if rows[0]["name"] != "Alice" || rows[0]["age"] != int64(30) || rows[0]["active"] != true {
+		t.Fatalf("unexpected first row %v", rows[0])
+	}
+	if joined, ok := rows[0]["joined"].(time.Time); !ok || joined.Day() != 2 {
+		t.Fatalf("expected parsed date, got %v", rows[0]["joined"])
+	}
+	if _, ok := rows[0]["extra"]; ok {
+		t.Fatalf("expected unmatched columns to be ignored")
+	}
+	if rows[1]["age"] != "old" || rows[1]["joined"] != nil {
+		t.Fatalf("unexpected second row %v", rows[1])
+	}
+	if len(warnings) != 2 || warnings[0].RowIndex != 1 || warnings[0].ColKey != "age" || warnings[1].ColKey != "active" {
+		t.Fatalf("unexpected warnings %+v", warnings)
+	}
+	result, err := RenderTableHTML(rows, loaderSchema, Options{})
+	if err != nil || !strings.Contains(result.HTML, ">Alice</td>") {
+		t.Fatalf("expected loaded rows to render, got %v %s", err, result.HTML)
+	}
+}
+
+func TestLoadJSONLines(t *testing.T) {
+	input := `{"name":"Alice","age":30,"active":true,"score":1.5}` + "\n\n" + `{"name":"Bob","age":"31","joined":"bad"}` + "\n"
+	rows, warnings, err := LoadJSONLines(strings.NewReader(input), loaderSchema)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["age"] != int64(30) || rows[1]["age"] != int64(31) || rows[0]["score"] != 1.5 {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+	if len(warnings) != 1 || warnings[0].ColKey != "joined" {
+		t.Fatalf("unexpected warnings %+v", warnings)
+	}
+	if _, _, err := LoadJSONLines(strings.NewReader("{broken"), loaderSchema); err == nil {
+		t.Fatalf("expected malformed input to fail")
+	}
+}
+
+func TestLoadListColumns(t *testing.T) {
+	schema := Schema[map[string]any]{Columns: []Column[map[string]any]{
+		{Key: "tags", Type: ColumnTypeTags},
+		{Key: "teams", Type: ColumnTypeEnumSet, Enum: &EnumSpec{Values: []string{"dev", "ops"}}},
+		{Key: "trend", Type: ColumnTypeSparkline},
+	}}
+	csvRows, _, err := LoadCSV(strings.NewReader("tags,teams,trend\n\"a, b\",\"ops,dev\",1 2\n"), schema)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	lines := `{"tags":["a","b"],"teams":["ops","dev"],"trend":[1,2]}` + "\n" + `{"tags":[1]}` + "\n"
+	jsonRows, warnings, err := LoadJSONLines(strings.NewReader(lines), schema)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].RowIndex != 1 || warnings[0].ColKey != "tags" {
+		t.Fatalf("expected a warning for non-string tags, got %+v", warnings)
+	}
+	for _, rows := range [][]map[string]any{csvRows, jsonRows[:1]} {
+		tags, _ := rows[0]["tags"].([]string)
+		teams, _ := rows[0]["teams"].([]string)
+		trend, _ := rows[0]["trend"].([]float64)
+		if strings.Join(tags, "|") != "a|b" || strings.Join(teams, "|") != "ops|dev" || len(trend) != 2 || trend[1] != 2 {
+			t.Fatalf("unexpected list values %#v", rows[0])
+		}
+		result, err := RenderTableHTML(rows, schema, Options{})
+		if err != nil || strings.Count(result.HTML, `class="extable-tag"`) != 2 || !strings.Contains(result.HTML, "<polyline") {
+			t.Fatalf("expected chips and a sparkline, got %v %s", err, result.HTML)
+		}
+	}
+}
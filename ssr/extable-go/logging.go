@@ -0,0 +1,74 @@
+package extable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func (o *Options) debug(msg string, args ...any) {
+	if o.Logger != nil {
+		o.Logger.Debug(msg, args...)
+	}
+}
+
+// traceSchema logs how the schema maps onto the row type, including columns
+// that will always render blank because no field matches their key.
+func traceSchema[T any](opts *Options, columns []Column[T], getter *fieldGetter) {
+	if opts.Logger == nil {
+		return
+	}
+	var zero T
+	opts.debug("extable: schema resolved", "rowType", reflect.TypeOf(zero).String(), "columns", len(columns))
+	for _, col := range columns {
+		if !col.derived() && !getter.hasKey(col.Key) {
+			opts.debug("extable: column key has no matching field", "col", col.Key)
+		}
+	}
+}
+
+// traceCoercion logs values whose Go type the column type cannot interpret,
+// which then render through the generic text fallback.
+func traceCoercion[T any](opts *Options, rowIndex int, col Column[T], value any) {
+	if opts.Logger == nil || value == nil || valueFitsColumn(value, col) {
+		return
+	}
+	opts.debug("extable: value does not match column type; using text fallback",
+		"row", rowIndex, "col", col.Key, "type", string(col.Type), "valueType", fmt.Sprintf("%T", value))
+}
+
+func valueFitsColumn[T any](value any, col Column[T]) bool {
+	switch col.Type {
+	case ColumnTypeBoolean:
+		_, ok := booleanValue(value, col.Format)
+		return ok
+	case ColumnTypeNumber, ColumnTypeInt, ColumnTypeUint:
+		_, ok := numericValue(value)
+		return ok
+	case ColumnTypeDate, ColumnTypeTime, ColumnTypeDateTime, ColumnTypePeriod:
+		_, ok := timeValue(value, col.Format)
+		return ok
+	case ColumnTypeSparkline:
+		_, ok := sparklineValues(value)
+		return ok
+	case ColumnTypeImage:
+		_, ok := imageValue(value)
+		return ok
+	case ColumnTypeFile:
+		_, ok := fileValues(value)
+		return ok
+	case ColumnTypeGeo:
+		_, ok := geoValue(value)
+		return ok
+	case ColumnTypeIP:
+		_, ok := ipValue(value)
+		return ok
+	case ColumnTypeSemver:
+		_, ok := semverValue(value)
+		return ok
+	case ColumnTypeTable:
+		_, ok := formatNestedTable(value, col.Table)
+		return ok
+	default:
+		return true
+	}
+}
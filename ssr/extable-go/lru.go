@@ -0,0 +1,60 @@
+package extable
+
+import (
+	"container/list"
+	"sync"
+)
+
+// maxCompiledSources bounds each process-wide cache of parsed schema sources,
+// so schemas built from request data cannot grow memory without limit.
+const maxCompiledSources = 1024
+
+// lruCache is a fixed-size, least-recently-used map safe for concurrent use.
+type lruCache[V any] struct {
+	mu      sync.Mutex
+	limit   int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+func newLRUCache[V any](limit int) *lruCache[V] {
+	return &lruCache[V]{limit: limit, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*lruEntry[V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *lruCache[V]) add(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		elem.Value.(*lruEntry[V]).value = value
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value})
+	if c.order.Len() > c.limit {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	}
+}
+
+func (c *lruCache[V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
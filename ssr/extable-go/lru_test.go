@@ -0,0 +1,47 @@
+package extable
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLRUCache[int](2)
+	cache.add("a", 1)
+	cache.add("b", 2)
+	if _, ok := cache.get("a"); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+	cache.add("c", 3)
+	if _, ok := cache.get("b"); ok {
+		t.Fatalf("expected b to be evicted")
+	}
+	if value, ok := cache.get("a"); !ok || value != 1 {
+		t.Fatalf("expected recently used a to survive, got %d %v", value, ok)
+	}
+	if cache.len() != 2 {
+		t.Fatalf("expected cache to stay at its limit, got %d", cache.len())
+	}
+}
+
+func TestTextTemplateCacheBounded(t *testing.T) {
+	for i := 0; i < maxCompiledSources+10; i++ {
+		if _, err := parseTextTemplate("{{.First}} " + strconv.Itoa(i)); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+	}
+	if n := textTemplates.len(); n > maxCompiledSources {
+		t.Fatalf("expected at most %d cached templates, got %d", maxCompiledSources, n)
+	}
+}
+
+func TestExprProgramCacheBounded(t *testing.T) {
+	for i := 0; i < maxCompiledSources+10; i++ {
+		if _, err := compileExpr("qty * "+strconv.Itoa(i), false); err != nil {
+			t.Fatalf("compile failed: %v", err)
+		}
+	}
+	if n := programs.len(); n > maxCompiledSources {
+		t.Fatalf("expected at most %d cached programs, got %d", maxCompiledSources, n)
+	}
+}
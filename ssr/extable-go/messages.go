@@ -0,0 +1,51 @@
+package extable
+
+// Messages holds the user-visible strings the renderer emits on its own.
+// Empty fields fall back to the English defaults.
+type Messages struct {
+	BooleanTrue  string
+	BooleanFalse string
+	// BooleanUnknown labels nil boolean values; empty by default.
+	BooleanUnknown string
+	// EmptyState is shown in a full-width row when there is no data; no row is rendered when empty.
+	EmptyState string
+	// Submit labels the submit button in FormMode.
+	Submit string
+	// AddRow labels the NewRowPlaceholder button.
+	AddRow string
+	// Actions is the accessible label of the ColumnTypeActions menu button.
+	Actions string
+}
+
+var defaultMessages = Messages{
+	BooleanTrue:  "true",
+	BooleanFalse: "false",
+	Submit:       "Save",
+	Actions:      "Actions",
+	AddRow:       "+ Add row",
+}
+
+func (o *Options) messages() Messages {
+	resolved := defaultMessages
+	if o.Messages == nil {
+		return resolved
+	}
+	if o.Messages.BooleanTrue != "" {
+		resolved.BooleanTrue = o.Messages.BooleanTrue
+	}
+	if o.Messages.BooleanFalse != "" {
+		resolved.BooleanFalse = o.Messages.BooleanFalse
+	}
+	if o.Messages.Submit != "" {
+		resolved.Submit = o.Messages.Submit
+	}
+	if o.Messages.AddRow != "" {
+		resolved.AddRow = o.Messages.AddRow
+	}
+	if o.Messages.Actions != "" {
+		resolved.Actions = o.Messages.Actions
+	}
+	resolved.BooleanUnknown = o.Messages.BooleanUnknown
+	resolved.EmptyState = o.Messages.EmptyState
+	return resolved
+}
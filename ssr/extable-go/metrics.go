@@ -0,0 +1,60 @@
+package extable
+
+import (
+	"runtime"
+	"time"
+)
+
+// RenderStats describes one completed render.
+type RenderStats struct {
+	Duration time.Duration
+	Rows     int
+	Bytes    int
+	Warnings int
+}
+
+// Stats is the cost of one render as seen by the Go runtime. Allocations and
+// Bytes are process-wide deltas, so they include other goroutines' work when
+// renders run concurrently.
+type Stats struct {
+	Allocations uint64
+	Bytes       uint64
+	Duration    time.Duration
+}
+
+type statsCollector struct {
+	start  time.Time
+	before runtime.MemStats
+}
+
+func startStats() *statsCollector {
+	c := &statsCollector{}
+	runtime.ReadMemStats(&c.before)
+	c.start = time.Now()
+	return c
+}
+
+func (c *statsCollector) stop() *Stats {
+	duration := time.Since(c.start)
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	return &Stats{
+		Allocations: after.Mallocs - c.before.Mallocs,
+		Bytes:       after.TotalAlloc - c.before.TotalAlloc,
+		Duration:    duration,
+	}
+}
+
+// Metrics receives a RenderStats after every successful render, for export
+// to Prometheus, OpenTelemetry or logs. Implementations must be safe for
+// concurrent use.
+type Metrics interface {
+	ObserveRender(stats RenderStats)
+}
+
+// MetricsFunc adapts a function to the Metrics interface.
+type MetricsFunc func(stats RenderStats)
+
+func (f MetricsFunc) ObserveRender(stats RenderStats) {
+	f(stats)
+}
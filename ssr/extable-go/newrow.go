@@ -0,0 +1,37 @@
+package extable
+
+import "strconv"
+
+// NewRowKey is the row key of NewRowPlaceholder controls in FormMode.
+// DecodeFormEdits returns the placeholder's fields under it; when every value
+// is blank (nil, or false for booleans) the row was left empty.
+const NewRowKey = "new"
+
+func (r *tableRenderer[T]) renderNewRow(builder *htmlBuilder) {
+	if r.opts.FormMode == nil {
+		builder.openTag("tr", "class", "extable-new-row", "data-new-row", "")
+		builder.openTag("td", "class", "extable-new-row-cell", "colspan", strconv.Itoa(len(r.columns)+1))
+		builder.openTag("button", "class", "extable-add-row", "type", "button", "data-action", "add-row")
+		builder.text(r.opts.messages().AddRow)
+		builder.closeTag("button")
+		builder.closeTag("td")
+		builder.closeTag("tr")
+		return
+	}
+	builder.openTag("tr", "class", "extable-new-row", "data-new-row", "")
+	builder.openTag("th", "class", "extable-row-header", "scope", "row")
+	builder.text("+")
+	builder.closeTag("th")
+	for _, col := range r.columns {
+		editable := !(col.Readonly || col.derived()) && hasFormControl(col.Type)
+		if !editable {
+			builder.openTag("td", "class", "extable-cell extable-readonly", "data-col-key", col.Key)
+			builder.closeTag("td")
+			continue
+		}
+		builder.openTag("td", "class", "extable-cell extable-editable", "data-col-key", col.Key)
+		r.renderNamedControl(builder, -1, col, formFieldName(NewRowKey, col.Key), nil)
+		builder.closeTag("td")
+	}
+	builder.closeTag("tr")
+}
@@ -0,0 +1,36 @@
+package extable
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewRowPlaceholder(t *testing.T) {
+	schema := Schema[exportRow]{Columns: []Column[exportRow]{
+		{Key: "id", Type: ColumnTypeInt, Readonly: true},
+		{Key: "name", Type: ColumnTypeString},
+	}}
+	result, err := RenderTableHTML([]exportRow{{ID: 1}}, schema, Options{NewRowPlaceholder: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<tr class="extable-new-row" data-new-row=""><td class="extable-new-row-cell" colspan="3"><button class="extable-add-row" type="button" data-action="add-row">+ Add row</button></td></tr></tbody>`) {
+		t.Fatalf("missing add-row placeholder: %s", result.HTML)
+	}
+	if result.Metadata.RowCount != 1 {
+		t.Fatalf("placeholder must not count as a row: %+v", result.Metadata)
+	}
+
+	result, err = RenderTableHTML([]exportRow{{ID: 1}}, schema, Options{NewRowPlaceholder: true, FormMode: &FormMode{Action: "/save"}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<td class="extable-cell extable-readonly" data-col-key="id"></td><td class="extable-cell extable-editable" data-col-key="name"><input type="text" class="extable-input" name="cell[new][name]" value="" aria-label="name"></td>`) {
+		t.Fatalf("missing form placeholder row: %s", result.HTML)
+	}
+	edits, warnings := DecodeFormEdits(url.Values{"cell[new][name]": {"Zoe"}, "cell[0][name]": {"a"}}, schema)
+	if len(warnings) != 0 || len(edits) != 2 || edits[1].RowKey != NewRowKey || edits[1].Value != "Zoe" {
+		t.Fatalf("unexpected edits: %+v %v", edits, warnings)
+	}
+}
@@ -0,0 +1,79 @@
+package extable
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// normalizeValue unwraps database and pointer wrappers so that formatting sees
+// the underlying value: invalid sql.Null* values and nil pointers become nil,
+// valid ones become their payload. Valuers that also format themselves, such
+// as custom enums and decimals, are kept so they render as their authors
+// intended. Pointers whose formatting methods have pointer receivers are
+// kept, since the pointee would lose them.
+func normalizeValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	if !hasFormatMethods(value) {
+		if valuer, ok := value.(driver.Valuer); ok {
+			if isNilPointer(value) {
+				return nil
+			}
+			unwrapped, err := valuer.Value()
+			if err != nil {
+				return value
+			}
+			if data, ok := unwrapped.([]byte); ok {
+				return string(data)
+			}
+			return unwrapped
+		}
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Ptr {
+		return value
+	}
+	if rv.IsNil() {
+		return nil
+	}
+	elem := rv.Elem().Interface()
+	if hasFormatMethods(value) && !hasFormatMethods(elem) {
+		return value
+	}
+	return normalizeValue(elem)
+}
+
+func hasFormatMethods(value any) bool {
+	switch value.(type) {
+	case encoding.TextMarshaler, fmt.Stringer:
+		return true
+	}
+	return false
+}
+
+func isNilPointer(value any) bool {
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// stringifyValue is the last-resort formatting for values no column type
+// understands. It prefers encoding.TextMarshaler, then fmt.Stringer, so types
+// like netip.Addr and uuid.UUID render the way their authors intended.
+func stringifyValue(value any) string {
+	switch v := value.(type) {
+	case encoding.TextMarshaler:
+		if !isNilPointer(value) {
+			if text, err := v.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+	case fmt.Stringer:
+		if !isNilPointer(value) {
+			return v.String()
+		}
+	}
+	return fmt.Sprint(value)
+}
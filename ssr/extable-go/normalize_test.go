@@ -0,0 +1,126 @@
+package extable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+type nullableRow struct {
+	Name    sql.NullString `json:"name"`
+	Count   sql.NullInt64  `json:"count"`
+	Seen    sql.NullTime   `json:"seen"`
+	Active  sql.NullBool   `json:"active"`
+	Nick    *string        `json:"nick"`
+	Score   *float64       `json:"score"`
+	Flagged *bool          `json:"flagged"`
+}
+
+func TestNullAndPointerValues(t *testing.T) {
+	nick := "ally"
+	score := 1.5
+	schema := Schema[nullableRow]{Columns: []Column[nullableRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "count", Type: ColumnTypeInt},
+		{Key: "seen", Type: ColumnTypeDate},
+		{Key: "active", Type: ColumnTypeBoolean},
+		{Key: "nick", Type: ColumnTypeString},
+		{Key: "score", Type: ColumnTypeNumber},
+		{Key: "flagged", Type: ColumnTypeBoolean},
+	}}
+	result, err := RenderTableHTML(
+		[]nullableRow{
+			{
+				Name:   sql.NullString{String: "Alice", Valid: true},
+				Count:  sql.NullInt64{Int64: 7, Valid: true},
+				Seen:   sql.NullTime{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+				Active: sql.NullBool{Bool: true, Valid: true},
+				Nick:   &nick,
+				Score:  &score,
+			},
+			{},
+		},
+		schema,
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{">Alice<", ">7<", ">2024-03-01<", ">true<", ">ally<", ">1.5<"} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s: %s", want, result.HTML)
+		}
+	}
+	if strings.Contains(result.HTML, "{") || strings.Contains(result.HTML, "0x") || strings.Contains(result.HTML, "&lt;nil&gt;") {
+		t.Fatalf("expected invalid values to render empty: %s", result.HTML)
+	}
+}
+
+type addrRow struct {
+	Addr netip.Addr `json:"addr"`
+	Mood mood       `json:"mood"`
+}
+
+type mood int
+
+func (m mood) String() string {
+	return [...]string{"calm", "angry"}[m]
+}
+
+func TestTextMarshalerAndStringerFallback(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]addrRow{{Addr: netip.MustParseAddr("2001:db8::1"), Mood: 1}},
+		Schema[addrRow]{Columns: []Column[addrRow]{
+			{Key: "addr", Type: ColumnTypeString},
+			{Key: "mood", Type: ColumnTypeString},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, ">2001:db8::1<") || !strings.Contains(result.HTML, ">angry<") {
+		t.Fatalf("expected marshaled values: %s", result.HTML)
+	}
+}
+
+type ptrStringer struct{ code string }
+
+func (s *ptrStringer) String() string { return "code:" + s.code }
+
+type ptrMarshaler struct{ id int }
+
+func (m *ptrMarshaler) MarshalText() ([]byte, error) {
+	return []byte("id-" + strings.Repeat("x", m.id)), nil
+}
+
+func TestPointerReceiverFormatting(t *testing.T) {
+	if got := stringifyValue(normalizeValue(&ptrStringer{code: "a1"})); got != "code:a1" {
+		t.Fatalf("pointer-receiver Stringer lost: %q", got)
+	}
+	if got := stringifyValue(normalizeValue(&ptrMarshaler{id: 2})); got != "id-xx" {
+		t.Fatalf("pointer-receiver TextMarshaler lost: %q", got)
+	}
+	addr := netip.MustParseAddr("10.0.0.1")
+	if got := normalizeValue(&addr); got != addr {
+		t.Fatalf("value-receiver types should still be dereferenced, got %#v", got)
+	}
+}
+
+type dbStatus int
+
+func (s dbStatus) Value() (driver.Value, error) { return int64(s), nil }
+
+func (s dbStatus) String() string { return [...]string{"draft", "published"}[s] }
+
+func TestValuerWithStringer(t *testing.T) {
+	if got := stringifyValue(normalizeValue(dbStatus(1))); got != "published" {
+		t.Fatalf("expected a Valuer with String to render via String, got %q", got)
+	}
+	if got := normalizeValue(sql.NullString{String: "x", Valid: true}); got != "x" {
+		t.Fatalf("expected sql.Null* values to unwrap, got %#v", got)
+	}
+}
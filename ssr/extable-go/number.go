@@ -0,0 +1,164 @@
+package extable
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+func formatFloatNumber(value float64, scale int, format *Format) string {
+	notation := NotationFixed
+	digits := 0
+	if format != nil {
+		if format.Notation != "" {
+			notation = format.Notation
+		}
+		digits = format.SignificantDigits
+	}
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	switch notation {
+	case NotationScientific:
+		return formatScientific(value, scale, digits)
+	case NotationEngineering:
+		return formatEngineering(value, scale, digits)
+	default:
+		if digits > 0 {
+			rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'e', digits-1, 64), 64)
+			return strconv.FormatFloat(rounded, 'f', -1, 64)
+		}
+		if scale >= 0 && format != nil && format.Rounding != "" {
+			return roundFixed(value, scale, format.Rounding)
+		}
+		return formatFloat(value, scale)
+	}
+}
+
+// roundFixed rounds the shortest decimal representation of value rather than
+// its binary expansion, so 2.675 rounds half-up to 2.68.
+func roundFixed(value float64, scale int, mode Rounding) string {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	negative := strings.HasPrefix(text, "-")
+	intPart, frac, _ := strings.Cut(strings.TrimPrefix(text, "-"), ".")
+	if len(frac) < scale {
+		frac += strings.Repeat("0", scale-len(frac))
+	}
+	digits := intPart + frac[:scale]
+	rest := frac[scale:]
+	roundUp := false
+	if rest != "" {
+		switch mode {
+		case RoundingHalfUp:
+			roundUp = rest[0] >= '5'
+		case RoundingHalfEven:
+			if rest == "5" {
+				roundUp = (digits[len(digits)-1]-'0')%2 == 1
+			} else {
+				roundUp = rest[0] >= '5'
+			}
+		}
+	}
+	if roundUp {
+		digits = incrementDigits(digits)
+	}
+	split := len(digits) - scale
+	result := digits[:split]
+	if scale > 0 {
+		result += "." + digits[split:]
+	}
+	if negative && strings.Trim(digits, "0") != "" {
+		result = "-" + result
+	}
+	return result
+}
+
+func incrementDigits(digits string) string {
+	b := []byte(digits)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < '9' {
+			b[i]++
+			return string(b)
+		}
+		b[i] = '0'
+	}
+	return "1" + string(b)
+}
+
+// formatScientific renders value as "1.23e+06". SignificantDigits counts the
+// leading digit; otherwise NumberScale sets the digits after the point.
+func formatScientific(value float64, scale int, digits int) string {
+	if digits > 0 {
+		return strconv.FormatFloat(value, 'e', digits-1, 64)
+	}
+	return strconv.FormatFloat(value, 'e', scale, 64)
+}
+
+// formatEngineering is scientific notation with the exponent restricted to
+// multiples of three, so the mantissa falls in [1, 1000).
+func formatEngineering(value float64, scale int, digits int) string {
+	if value == 0 {
+		return formatExponent(formatFloat(0, max(scale, 0)), 0)
+	}
+	exponent := int(math.Floor(math.Log10(math.Abs(value))/3)) * 3
+	// Trim the float error introduced by the division, e.g. 0.00042/1e-6.
+	mantissa, _ := strconv.ParseFloat(strconv.FormatFloat(value/math.Pow10(exponent), 'g', 15, 64), 64)
+	var text string
+	if digits > 0 {
+		intDigits := len(strconv.Itoa(int(math.Abs(mantissa))))
+		text = formatFloat(mantissa, max(digits-intDigits, 0))
+	} else {
+		text = formatFloat(mantissa, scale)
+	}
+	// Rounding can carry the mantissa up to 1000, e.g. 999.96 with one decimal.
+	if parsed, err := strconv.ParseFloat(text, 64); err == nil && math.Abs(parsed) >= 1000 {
+		return formatEngineering(parsed*math.Pow10(exponent), scale, digits)
+	}
+	return formatExponent(text, exponent)
+}
+
+func formatExponent(mantissa string, exponent int) string {
+	sign := "+"
+	if exponent < 0 {
+		sign = "-"
+		exponent = -exponent
+	}
+	exp := strconv.Itoa(exponent)
+	if len(exp) < 2 {
+		exp = strings.Repeat("0", 2-len(exp)) + exp
+	}
+	return mantissa + "e" + sign + exp
+}
+
+func applyNegativeStyle(text string, format *Format) string {
+	if format == nil || format.NegativeStyle != NegativeParentheses {
+		return text
+	}
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		return "(" + rest + ")"
+	}
+	return text
+}
+
+func isNegative(value any) bool {
+	number, ok := numericValue(value)
+	return ok && number < 0
+}
+
+func renderNumberText(builder *htmlBuilder, text string, format *Format) {
+	if format == nil || text == "" {
+		builder.text(text)
+		return
+	}
+	if format.Prefix != "" {
+		builder.openTag("span", "class", "extable-unit extable-unit-prefix")
+		builder.text(format.Prefix)
+		builder.closeTag("span")
+	}
+	builder.text(text)
+	if format.Suffix != "" {
+		builder.openTag("span", "class", "extable-unit extable-unit-suffix")
+		builder.text(format.Suffix)
+		builder.closeTag("span")
+	}
+}
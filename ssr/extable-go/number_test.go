@@ -0,0 +1,98 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatNotation(t *testing.T) {
+	scale := 2
+	cases := []struct {
+		value  float64
+		format *Format
+		want   string
+	}{
+		{1234567, &Format{Notation: NotationScientific, SignificantDigits: 3}, "1.23e+06"},
+		{1234567, &Format{Notation: NotationScientific, NumberScale: &scale}, "1.23e+06"},
+		{0.000123, &Format{Notation: NotationScientific}, "1.23e-04"},
+		{1234567, &Format{Notation: NotationEngineering, SignificantDigits: 4}, "1.235e+06"},
+		{12345, &Format{Notation: NotationEngineering, SignificantDigits: 3}, "12.3e+03"},
+		{0.00042, &Format{Notation: NotationEngineering}, "420e-06"},
+		{999960, &Format{Notation: NotationEngineering, SignificantDigits: 4}, "1.000e+06"},
+		{123.456, &Format{SignificantDigits: 4}, "123.5"},
+		{123456, &Format{SignificantDigits: 2}, "120000"},
+	}
+	for _, c := range cases {
+		if got := formatNumber(c.value, c.format); got != c.want {
+			t.Errorf("formatNumber(%v, %+v) = %q, want %q", c.value, c.format, got, c.want)
+		}
+	}
+}
+
+type balanceRow struct {
+	Amount float64 `extable:"amount"`
+}
+
+func TestNegativeStyle(t *testing.T) {
+	scale := 2
+	schema := Schema[balanceRow]{Columns: []Column[balanceRow]{{
+		Key:    "amount",
+		Type:   ColumnTypeNumber,
+		Format: &Format{NumberScale: &scale, NegativeStyle: NegativeParentheses, NegativeClass: true},
+	}}}
+	result, err := RenderTableHTML([]balanceRow{{Amount: -12.5}, {Amount: 3}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(result.HTML, "(12.50)") {
+		t.Fatalf("expected parenthesized negative, got %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "extable-negative") != 1 {
+		t.Fatalf("expected exactly one negative cell, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, ">3.00<") {
+		t.Fatalf("expected positive value unchanged, got %s", result.HTML)
+	}
+}
+
+func TestNumberPrefixSuffix(t *testing.T) {
+	schema := Schema[balanceRow]{Columns: []Column[balanceRow]{{
+		Key:    "amount",
+		Type:   ColumnTypeNumber,
+		Format: &Format{Prefix: "$", Suffix: " USD"},
+	}}}
+	result, err := RenderTableHTML([]balanceRow{{Amount: 42}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `<span class="extable-unit extable-unit-prefix">$</span>42<span class="extable-unit extable-unit-suffix"> USD</span>`
+	if !strings.Contains(result.HTML, want) {
+		t.Fatalf("expected unit spans, got %s", result.HTML)
+	}
+}
+
+func TestRounding(t *testing.T) {
+	two := 2
+	zero := 0
+	cases := []struct {
+		value float64
+		scale *int
+		mode  Rounding
+		want  string
+	}{
+		{2.675, &two, RoundingHalfUp, "2.68"},
+		{2.665, &two, RoundingHalfEven, "2.66"},
+		{2.675, &two, RoundingHalfEven, "2.68"},
+		{2.679, &two, RoundingTruncate, "2.67"},
+		{-2.5, &zero, RoundingHalfUp, "-3"},
+		{-0.004, &two, RoundingHalfUp, "0.00"},
+		{9.995, &two, RoundingHalfUp, "10.00"},
+		{1.5, &two, RoundingHalfUp, "1.50"},
+	}
+	for _, c := range cases {
+		got := formatNumber(c.value, &Format{NumberScale: c.scale, Rounding: c.mode})
+		if got != c.want {
+			t.Errorf("formatNumber(%v, %s) = %q, want %q", c.value, c.mode, got, c.want)
+		}
+	}
+}
@@ -1,9 +1,167 @@
 package extable
 
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Direction string
+
+const (
+	DirectionLTR Direction = "ltr"
+	DirectionRTL Direction = "rtl"
+)
+
 type Options struct {
 	WrapWithRoot bool
 	DefaultClass []string
 	DefaultStyle map[string]string
+	// EmitState appends the column state as a JSON script for client hydration.
+	EmitState bool
+	Messages  *Messages
+	Direction Direction
+	Sort      []SortSpec
+	// Collator orders string columns during Sort; byte order is used when nil.
+	Collator Collator
+	// NaturalSort compares embedded digit runs numerically ("item2" before "item10").
+	NaturalSort bool
+	// Indent, when non-empty, pretty-prints block elements one per line using
+	// this string per nesting level. Output is compact by default.
+	Indent string
+	// URLPolicy filters every href and src the renderer emits; DefaultURLPolicy is used when nil.
+	URLPolicy *URLPolicy
+	// Sanitizer cleans rich text cells; BasicSanitizer is used when nil.
+	Sanitizer Sanitizer
+	Hooks     *Hooks
+	Highlight *Highlight
+	// Offset and Limit render a window of the (filtered) rows; Limit 0 means no limit.
+	// Row headers keep their position in the full list.
+	Offset int
+	Limit  int
+	// EmitRowHash adds data-row-hash to each row and collects the hashes in Metadata.RowHashes.
+	EmitRowHash bool
+	// ChecksumIgnoreAttrs names attributes left out of Metadata.Checksum.
+	ChecksumIgnoreAttrs []string
+	CSRF                *CSRF
+	// EmitCellCoordinates adds data-row-index, and data-row-id when Schema.RowID is set, to every cell.
+	EmitCellCoordinates bool
+	Metrics             Metrics
+	// CollectStats fills Metadata.Stats. It reads runtime memory statistics
+	// around the render, which briefly stops the world, so leave it off in
+	// hot paths.
+	CollectStats bool
+	// Logger receives debug-level events about schema resolution, type fallbacks and warnings.
+	Logger *slog.Logger
+	// Responsive emits the markup CSS needs to reflow the table on small screens.
+	Responsive *Responsive
+	// StickyHeader and StickyRowHeader pin the header row and row-number column
+	// with position: sticky, like the client's frozen panes.
+	StickyHeader    bool
+	StickyRowHeader bool
+	// FrozenHeaderRows pins only the first n header rows (the Column.HeaderGroup
+	// row counts as one), each offset by the rows above it; it takes precedence
+	// over StickyHeader.
+	FrozenHeaderRows int
+	Stripe           *Stripe
+	// KeyboardNav adds tabindex and data-nav="row,col" to body cells so a small
+	// script can move focus with the arrow keys; only the first cell is tabbable.
+	KeyboardNav bool
+	// Caption names the table for assistive technology.
+	Caption string
+	// RowHeight, in pixels, fixes the height of every data row to match the
+	// client's virtual scroller; it is emitted as a style and data-row-height.
+	RowHeight int
+	FormMode  *FormMode
+	// Theme is emitted as data-extable-theme for theme stylesheets to select on.
+	Theme string
+	// Locale is emitted as the lang attribute.
+	Locale string
+	// Location converts datetime values before formatting; values keep their own zone when nil.
+	Location *time.Location
+	// ClassPrefix replaces the "extable-" prefix of every class the renderer
+	// emits, to isolate tenant stylesheets. The client script expects the
+	// default names, so use it only for static output.
+	ClassPrefix string
+	// IDPrefix namespaces the element ids the renderer generates, such as the
+	// column description targets of aria-describedby; "extable" when empty.
+	// Give each table on a page its own prefix. AddTable uses the section ID.
+	IDPrefix string
+	// GroupBy names a column whose values split the body into groups, each
+	// introduced by a header row; groups keep the order of their first row.
+	GroupBy string
+	// CollapsedGroups lists group values (as in the header's data-group) whose
+	// rows are rendered with the hidden attribute.
+	CollapsedGroups []string
+	// PreviousValues, from TakeSnapshot of the previous dataset, adds
+	// data-prev-value to numeric cells whose value changed, so the client can
+	// animate the change. It needs Schema.RowID.
+	PreviousValues Snapshot
+	// CellErrors renders server-side validation messages into cells, for
+	// re-rendering a rejected submission.
+	CellErrors map[CellRef]string
+	// DirtyCells marks cells with unsaved edits known to the server, such as
+	// draft records, the way the client marks its own pending edits.
+	DirtyCells []CellRef
+	// NewRowPlaceholder appends an add-record row to the body: empty controls
+	// named cell[new][<col>] in FormMode, otherwise an add-row button for the
+	// client runtime.
+	NewRowPlaceholder bool
+	// Timeout stops writing rows once a render has run this long; the table is
+	// closed after the rows written so far and Metadata.TimedOut is set.
+	Timeout time.Duration
+
+	rowCache *rowCache
+	ctx      context.Context
+}
+
+type ResponsiveMode string
+
+const ResponsiveStack ResponsiveMode = "stack"
+
+// Responsive in stack mode labels every cell with data-label (its column
+// header) and marks the table with data-responsive and data-breakpoint, so a
+// media query can render each row as a card.
+type Responsive struct {
+	Mode ResponsiveMode
+	// Breakpoint is the viewport width in pixels below which rows stack.
+	Breakpoint int
+}
+
+// Stripe bands rendered rows server-side: every other run of Every rows gets
+// Class, counted over the rows actually emitted so filtering and paging never
+// break the pattern. With GroupBy the count restarts after each group header.
+type Stripe struct {
+	// Every is the band height in rows; 1 when zero.
+	Every int
+	// Class is added to rows in odd bands; "extable-stripe" when empty.
+	Class string
+}
+
+// CSRF embeds a token for action buttons: a hidden input next to each button
+// for no-JS form posts, and data-csrf-* attributes on the table for scripts.
+type CSRF struct {
+	Token string
+	// FieldName is the form field carrying the token; "csrf_token" when empty.
+	FieldName string
+}
+
+func (c *CSRF) fieldName() string {
+	if c.FieldName == "" {
+		return "csrf_token"
+	}
+	return c.FieldName
+}
+
+func (o *Options) isRTL() bool {
+	return o.Direction == DirectionRTL
+}
+
+func (o *Options) idPrefix() string {
+	if o.IDPrefix != "" {
+		return o.IDPrefix
+	}
+	return "extable"
 }
 
 type Result struct {
@@ -15,10 +173,32 @@ type Metadata struct {
 	RowCount    int
 	ColumnCount int
 	Warnings    []Warning
+	// TruncatedCount is the number of cells shortened by Column.MaxChars.
+	TruncatedCount int
+	// FilteredCount is the number of rows skipped by Schema.RowFilter.
+	FilteredCount int
+	// TotalRows counts rows passing Schema.RowFilter before Offset and Limit are applied.
+	TotalRows int
+	// RowHashes holds one hash per rendered row, in order, when Options.EmitRowHash is set.
+	RowHashes []string
+	// Checksum is the hex SHA-256 of the rendered HTML, usable as an ETag.
+	Checksum string
+	// CacheHits counts rows served from the cache by RenderCached.
+	CacheHits int
+	// ColumnWidths holds, per column, the longest header or formatted cell text
+	// of the rendered rows, in runes.
+	ColumnWidths []int
+	// TimedOut reports that Options.Timeout or the context ended the render
+	// early; RowCount and TotalRows then cover only the rows reached.
+	TimedOut bool
+	// Stats is set when Options.CollectStats is.
+	Stats *Stats
 }
 
 type Warning struct {
-	RowIndex int
-	ColKey   string
-	Message  string
+	// RowIndex is -1 for problems not tied to a row, such as dropped attributes.
+	RowIndex  int
+	ColKey    string
+	Message   string
+	SectionID string
 }
@@ -0,0 +1,36 @@
+package extable
+
+// RenderHead renders only the <thead> of the table RenderTableHTML would
+// produce for schema and opts, for layouts that place the header in its own
+// scrolling element or reuse it across pages. WrapWithRoot, Caption and
+// EmitState are not applied.
+func RenderHead[T any](schema Schema[T], opts Options) (Result, error) {
+	r, _, _, err := prepareTable[T](nil, schema, &opts)
+	if err != nil {
+		return Result{}, err
+	}
+	builder := newHTMLBuilder(opts.Indent, 0)
+	builder.classPrefix = opts.ClassPrefix
+	r.renderHead(builder)
+	return r.partResult(builder, 0, 0, 0), nil
+}
+
+// RenderBody renders only the <tbody>, with the same sorting, filtering,
+// paging and row numbering as RenderTableHTML.
+func RenderBody[T any](data []T, schema Schema[T], opts Options) (Result, error) {
+	r, data, nodes, err := prepareTable(data, schema, &opts)
+	if err != nil {
+		return Result{}, err
+	}
+	builder := newHTMLBuilder(opts.Indent, 0)
+	builder.classPrefix = opts.ClassPrefix
+	total, rendered := r.renderBody(builder, data, nodes)
+	return r.partResult(builder, len(data), total, rendered), nil
+}
+
+func (r *tableRenderer[T]) partResult(builder *htmlBuilder, rows, total, rendered int) Result {
+	metadata := r.metadata(builder, rows, total, rendered)
+	html := builder.string()
+	metadata.Checksum = checksum(html, r.opts.ChecksumIgnoreAttrs)
+	return Result{HTML: html, Metadata: metadata}
+}
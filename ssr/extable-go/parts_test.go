@@ -0,0 +1,36 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHeadAndBody(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString, Header: "Name"},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	rows := []sampleRow{{Name: "b", Age: 2}, {Name: "a", Age: 1}, {Name: "c", Age: 3}}
+	opts := Options{Sort: []SortSpec{{Key: "age"}}, Offset: 1}
+	full, err := RenderTableHTML(rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	head, err := RenderHead(schema, opts)
+	if err != nil {
+		t.Fatalf("render head failed: %v", err)
+	}
+	body, err := RenderBody(rows, schema, opts)
+	if err != nil {
+		t.Fatalf("render body failed: %v", err)
+	}
+	if !strings.HasPrefix(head.HTML, "<thead>") || !strings.HasPrefix(body.HTML, "<tbody>") {
+		t.Fatalf("unexpected parts:\n%s\n%s", head.HTML, body.HTML)
+	}
+	if full.HTML != "<table>"+head.HTML+body.HTML+"</table>" {
+		t.Fatalf("parts do not compose into the full table:\n%s\n%s%s", full.HTML, head.HTML, body.HTML)
+	}
+	if body.Metadata.RowCount != 2 || body.Metadata.TotalRows != 3 || body.Metadata.Checksum == "" {
+		t.Fatalf("unexpected body metadata %+v", body.Metadata)
+	}
+}
@@ -0,0 +1,105 @@
+package extable
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PatchOperation is an RFC 6902 JSON Patch operation. Paths have the form
+// /<rowID> for whole rows and /<rowID>/<colKey> for cells.
+type PatchOperation struct {
+	Op    string
+	Path  string
+	Value any
+}
+
+func (p PatchOperation) MarshalJSON() ([]byte, error) {
+	if p.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{p.Op, p.Path})
+	}
+	return json.Marshal(struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}{p.Op, p.Path, p.Value})
+}
+
+// ComputePatch returns the operations turning before into after, matching
+// rows with Schema.RowID. Removed rows come first, then added rows and cell
+// replacements in after order. Formula, virtual and template columns are
+// derived and left out, as are columns hidden by Schema.ColumnVisible.
+func ComputePatch[T any](before, after []T, schema Schema[T]) ([]PatchOperation, error) {
+	if schema.RowID == nil {
+		return nil, ErrMissingRowID
+	}
+	getter, err := newFieldGetter[T]()
+	if err != nil {
+		return nil, err
+	}
+	// Dynamic columns cover both datasets so added and removed values compare.
+	schema = schema.withDynamicColumns(append(append([]T(nil), before...), after...))
+	columns := make([]Column[T], 0, len(schema.Columns))
+	for _, col := range schema.Columns {
+		if !col.derived() && schema.columnVisible(col) {
+			columns = append(columns, col)
+		}
+	}
+	beforeByID, err := indexRowIDs(before, schema.RowID)
+	if err != nil {
+		return nil, err
+	}
+	afterByID, err := indexRowIDs(after, schema.RowID)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]PatchOperation, 0)
+	for _, row := range before {
+		id := schema.RowID(row)
+		if _, ok := afterByID[id]; !ok {
+			ops = append(ops, PatchOperation{Op: "remove", Path: "/" + escapeJSONPointer(id)})
+		}
+	}
+	for _, row := range after {
+		id := schema.RowID(row)
+		path := "/" + escapeJSONPointer(id)
+		i, existed := beforeByID[id]
+		if !existed {
+			values := make(map[string]any, len(columns))
+			for _, col := range columns {
+				values[col.Key], _ = getter.valueForKey(row, col.Key)
+			}
+			ops = append(ops, PatchOperation{Op: "add", Path: path, Value: values})
+			continue
+		}
+		for _, col := range columns {
+			old, _ := getter.valueForKey(before[i], col.Key)
+			value, _ := getter.valueForKey(row, col.Key)
+			if !reflect.DeepEqual(old, value) {
+				ops = append(ops, PatchOperation{Op: "replace", Path: path + "/" + escapeJSONPointer(col.Key), Value: value})
+			}
+		}
+	}
+	return ops, nil
+}
+
+func indexRowIDs[T any](rows []T, rowID func(T) string) (map[string]int, error) {
+	index := make(map[string]int, len(rows))
+	for i, row := range rows {
+		id := rowID(row)
+		if _, dup := index[id]; dup {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateRowID, id)
+		}
+		index[id] = i
+	}
+	return index, nil
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
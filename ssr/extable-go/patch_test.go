@@ -0,0 +1,43 @@
+package extable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type patchRow struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestComputePatch(t *testing.T) {
+	schema := Schema[patchRow]{
+		Columns: []Column[patchRow]{
+			{Key: "name", Type: ColumnTypeString},
+			{Key: "age", Type: ColumnTypeInt},
+			{Key: "label", Type: ColumnTypeString, Virtual: true, Formula: func(row patchRow) any { return row.Name }},
+		},
+		RowID: func(row patchRow) string { return row.ID },
+	}
+	before := []patchRow{{ID: "1", Name: "Alice", Age: 30}, {ID: "a/b", Name: "Gone", Age: 1}}
+	after := []patchRow{{ID: "1", Name: "Alice", Age: 31}, {ID: "2", Name: "Bob", Age: 0}}
+	ops, err := ComputePatch(before, after, schema)
+	if err != nil {
+		t.Fatalf("compute failed: %v", err)
+	}
+	data, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	want := `[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/1/age","value":31},{"op":"add","path":"/2","value":{"age":0,"name":"Bob"}}]`
+	if string(data) != want {
+		t.Fatalf("unexpected patch:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestComputePatchRequiresRowID(t *testing.T) {
+	if _, err := ComputePatch([]patchRow{}, []patchRow{}, Schema[patchRow]{}); err == nil {
+		t.Fatalf("expected missing RowID error")
+	}
+}
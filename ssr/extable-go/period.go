@@ -0,0 +1,97 @@
+package extable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PeriodGranularity string
+
+const (
+	PeriodWeek    PeriodGranularity = "week"
+	PeriodMonth   PeriodGranularity = "month"
+	PeriodQuarter PeriodGranularity = "quarter"
+	PeriodYear    PeriodGranularity = "year"
+)
+
+// PeriodSpec configures ColumnTypePeriod; Granularity is month when empty.
+// Weeks are ISO 8601 weeks ("2024-W07"), quarters render as "Q3 2024".
+type PeriodSpec struct {
+	Granularity PeriodGranularity `json:"granularity,omitempty"`
+}
+
+func formatPeriod(t time.Time, spec *PeriodSpec) string {
+	granularity := PeriodMonth
+	if spec != nil && spec.Granularity != "" {
+		granularity = spec.Granularity
+	}
+	switch granularity {
+	case PeriodWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case PeriodQuarter:
+		return "Q" + strconv.Itoa((int(t.Month())-1)/3+1) + " " + strconv.Itoa(t.Year())
+	case PeriodYear:
+		return strconv.Itoa(t.Year())
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+// parsePeriod reads the strings formatPeriod writes, plus "2024-Q3", as the
+// first day of the period.
+func parsePeriod(text string) (time.Time, bool) {
+	if t, err := time.Parse("2006-01", text); err == nil {
+		return t, true
+	}
+	if len(text) == 4 {
+		if t, err := time.Parse("2006", text); err == nil {
+			return t, true
+		}
+	}
+	if yearText, weekText, ok := strings.Cut(text, "-W"); ok {
+		year, errYear := strconv.Atoi(yearText)
+		week, errWeek := strconv.Atoi(weekText)
+		if errYear != nil || errWeek != nil || week < 1 || week > 53 {
+			return time.Time{}, false
+		}
+		// January 4th is always in ISO week 1.
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		t := monday.AddDate(0, 0, (week-1)*7)
+		if y, w := t.ISOWeek(); y != year || w != week {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	var quarterText, yearText string
+	if q, y, ok := strings.Cut(text, " "); ok && strings.HasPrefix(q, "Q") {
+		quarterText, yearText = q[1:], y
+	} else if y, q, ok := strings.Cut(text, "-Q"); ok {
+		quarterText, yearText = q, y
+	} else {
+		return time.Time{}, false
+	}
+	quarter, errQuarter := strconv.Atoi(quarterText)
+	year, errYear := strconv.Atoi(yearText)
+	if errQuarter != nil || errYear != nil || quarter < 1 || quarter > 4 {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC), true
+}
+
+func checkPeriods[T any](columns []Column[T]) error {
+	for _, col := range columns {
+		if col.Type != ColumnTypePeriod || col.Period == nil {
+			continue
+		}
+		switch col.Period.Granularity {
+		case "", PeriodWeek, PeriodMonth, PeriodQuarter, PeriodYear:
+		default:
+			return &SchemaError{ColKey: col.Key, Message: fmt.Sprintf("has unknown period granularity %q", col.Period.Granularity)}
+		}
+	}
+	return nil
+}
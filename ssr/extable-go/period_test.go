@@ -0,0 +1,54 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatPeriod(t *testing.T) {
+	day := time.Date(2024, time.February, 14, 9, 0, 0, 0, time.UTC)
+	cases := map[PeriodGranularity]string{
+		"":            "2024-02",
+		PeriodWeek:    "2024-W07",
+		PeriodQuarter: "Q1 2024",
+		PeriodYear:    "2024",
+	}
+	for granularity, want := range cases {
+		spec := &PeriodSpec{Granularity: granularity}
+		got := formatPeriod(day, spec)
+		if got != want {
+			t.Fatalf("%s: got %q, want %q", granularity, got, want)
+		}
+		start, ok := parsePeriod(got)
+		if !ok || formatPeriod(start, spec) != got {
+			t.Fatalf("%s: %q does not round-trip, got %v", granularity, got, start)
+		}
+	}
+	if got := formatPeriod(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), &PeriodSpec{Granularity: PeriodWeek}); got != "2020-W53" {
+		t.Fatalf("expected ISO week-year, got %q", got)
+	}
+	if _, ok := parsePeriod("2024-W60"); ok {
+		t.Fatalf("expected invalid week to be rejected")
+	}
+}
+
+func TestPeriodColumn(t *testing.T) {
+	type report struct {
+		Month time.Time `json:"month"`
+	}
+	schema := Schema[report]{Columns: []Column[report]{
+		{Key: "month", Type: ColumnTypePeriod, Period: &PeriodSpec{Granularity: PeriodQuarter}},
+	}}
+	result, err := RenderTableHTML([]report{{Month: time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC)}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, ">Q3 2024</td>") || len(result.Metadata.Warnings) != 0 {
+		t.Fatalf("unexpected output: %s %v", result.HTML, result.Metadata.Warnings)
+	}
+	schema.Columns[0].Period.Granularity = "decade"
+	if err := schema.Validate(); err == nil {
+		t.Fatalf("expected unknown granularity to be rejected")
+	}
+}
@@ -0,0 +1,162 @@
+package extable
+
+import "fmt"
+
+type Aggregation string
+
+const (
+	AggregateSum   Aggregation = "sum"
+	AggregateCount Aggregation = "count"
+	AggregateAvg   Aggregation = "avg"
+	AggregateMin   Aggregation = "min"
+	AggregateMax   Aggregation = "max"
+)
+
+type PivotSpec struct {
+	RowKey      string
+	ColumnKey   string
+	ValueKey    string
+	Aggregation Aggregation
+	RowHeader   string
+	Format      *Format
+}
+
+type pivotCell struct {
+	count int
+	sum   float64
+	min   float64
+	max   float64
+}
+
+// RenderPivot renders a cross-tab of data: one row per distinct RowKey value
+// and one generated column per distinct ColumnKey value, in order of first
+// appearance.
+func RenderPivot[T any](data []T, spec PivotSpec, opts Options) (Result, error) {
+	if spec.RowKey == "" || spec.ColumnKey == "" || spec.ValueKey == "" {
+		return Result{}, ErrMissingPivotKeys
+	}
+	aggregation := spec.Aggregation
+	if aggregation == "" {
+		aggregation = AggregateSum
+	}
+	switch aggregation {
+	case AggregateSum, AggregateCount, AggregateAvg, AggregateMin, AggregateMax:
+	default:
+		return Result{}, fmt.Errorf("%w %q", ErrUnsupportedAggregation, aggregation)
+	}
+	getter, err := newFieldGetter[T]()
+	if err != nil {
+		return Result{}, err
+	}
+	for _, key := range []string{spec.RowKey, spec.ColumnKey, spec.ValueKey} {
+		if !getter.hasKey(key) {
+			return Result{}, &SchemaError{ColKey: key, Message: "is a pivot key but not found in row type"}
+		}
+	}
+
+	rowLabels := make([]string, 0)
+	colLabels := make([]string, 0)
+	cells := make(map[string]map[string]*pivotCell)
+	seenCols := make(map[string]bool)
+	warnings := make([]Warning, 0)
+	for rowIndex, row := range data {
+		rowValue, _ := getter.valueForKey(row, spec.RowKey)
+		colValue, _ := getter.valueForKey(row, spec.ColumnKey)
+		value, _ := getter.valueForKey(row, spec.ValueKey)
+		rowLabel := pivotLabel(rowValue)
+		colLabel := pivotLabel(colValue)
+
+		rowCells, ok := cells[rowLabel]
+		if !ok {
+			rowCells = make(map[string]*pivotCell)
+			cells[rowLabel] = rowCells
+			rowLabels = append(rowLabels, rowLabel)
+		}
+		if !seenCols[colLabel] {
+			seenCols[colLabel] = true
+			colLabels = append(colLabels, colLabel)
+		}
+		if value == nil {
+			continue
+		}
+		number, isNumber := numericValue(value)
+		if !isNumber && aggregation != AggregateCount {
+			warnings = append(warnings, Warning{
+				RowIndex: rowIndex,
+				ColKey:   spec.ValueKey,
+				Message:  "pivot value is not numeric",
+			})
+			continue
+		}
+		cell, ok := rowCells[colLabel]
+		if !ok {
+			cell = &pivotCell{min: number, max: number}
+			rowCells[colLabel] = cell
+		}
+		cell.count += 1
+		cell.sum += number
+		if number < cell.min {
+			cell.min = number
+		}
+		if number > cell.max {
+			cell.max = number
+		}
+	}
+
+	valueType := ColumnTypeNumber
+	if aggregation == AggregateCount {
+		valueType = ColumnTypeInt
+	}
+	rowHeader := spec.RowHeader
+	if rowHeader == "" {
+		rowHeader = spec.RowKey
+	}
+	columns := []Column[map[string]any]{{Key: spec.RowKey, Type: ColumnTypeString, Header: rowHeader, Readonly: true}}
+	for _, label := range colLabels {
+		columns = append(columns, Column[map[string]any]{
+			Key:      label,
+			Type:     valueType,
+			Header:   label,
+			Readonly: true,
+			Format:   spec.Format,
+		})
+	}
+
+	rows := make([]map[string]any, 0, len(rowLabels))
+	for _, rowLabel := range rowLabels {
+		row := map[string]any{spec.RowKey: rowLabel}
+		for colLabel, cell := range cells[rowLabel] {
+			row[colLabel] = cell.result(aggregation)
+		}
+		rows = append(rows, row)
+	}
+
+	result, err := RenderTableHTML(rows, Schema[map[string]any]{Columns: columns}, opts)
+	if err != nil {
+		return Result{}, err
+	}
+	result.Metadata.Warnings = append(warnings, result.Metadata.Warnings...)
+	return result, nil
+}
+
+func (c *pivotCell) result(aggregation Aggregation) any {
+	switch aggregation {
+	case AggregateCount:
+		return c.count
+	case AggregateAvg:
+		return c.sum / float64(c.count)
+	case AggregateMin:
+		return c.min
+	case AggregateMax:
+		return c.max
+	default:
+		return c.sum
+	}
+}
+
+func pivotLabel(value any) string {
+	if value == nil {
+		return ""
+	}
+	return stringifyValue(value)
+}
@@ -0,0 +1,61 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+type salesRow struct {
+	Region string  `json:"region"`
+	Month  string  `json:"month"`
+	Amount float64 `json:"amount"`
+}
+
+func TestRenderPivotSum(t *testing.T) {
+	result, err := RenderPivot(
+		[]salesRow{
+			{Region: "East", Month: "Jan", Amount: 10},
+			{Region: "West", Month: "Feb", Amount: 5},
+			{Region: "East", Month: "Jan", Amount: 2.5},
+			{Region: "East", Month: "Feb", Amount: 1},
+		},
+		PivotSpec{RowKey: "region", ColumnKey: "month", ValueKey: "amount", RowHeader: "Region"},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if result.Metadata.RowCount != 2 || result.Metadata.ColumnCount != 3 {
+		t.Fatalf("unexpected metadata: %+v", result.Metadata)
+	}
+	if !strings.Contains(result.HTML, "<span class=\"extable-col-header-text\">Jan</span>") {
+		t.Fatalf("expected generated column header")
+	}
+	if !strings.Contains(result.HTML, "data-col-key=\"Jan\">12.5</td>") {
+		t.Fatalf("expected aggregated value: %s", result.HTML)
+	}
+	if strings.Index(result.HTML, ">Jan<") > strings.Index(result.HTML, ">Feb<") {
+		t.Fatalf("expected columns in first-appearance order")
+	}
+}
+
+func TestRenderPivotUnknownKey(t *testing.T) {
+	_, err := RenderPivot([]salesRow{}, PivotSpec{RowKey: "region", ColumnKey: "day", ValueKey: "amount"}, Options{})
+	if err == nil {
+		t.Fatalf("expected error for unknown key")
+	}
+}
+
+func TestRenderMapRows(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]map[string]any{{"name": "Alice"}},
+		Schema[map[string]any]{Columns: []Column[map[string]any]{{Key: "name", Type: ColumnTypeString}}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, "Alice") {
+		t.Fatalf("expected map value")
+	}
+}
@@ -0,0 +1,120 @@
+package extable
+
+import "time"
+
+// Profile bundles presentation defaults, such as one tenant's theme and
+// locale, so they are configured once instead of at every call site.
+type Profile struct {
+	Theme        string
+	Locale       string
+	Location     *time.Location
+	ClassPrefix  string
+	Direction    Direction
+	Messages     *Messages
+	DefaultClass []string
+	DefaultStyle map[string]string
+	URLPolicy    *URLPolicy
+	Collator     Collator
+}
+
+// Merge returns p with every field set in override replacing its own.
+// Messages are merged per string; slices and maps are replaced whole.
+func (p Profile) Merge(override Profile) Profile {
+	merged := p
+	if override.Theme != "" {
+		merged.Theme = override.Theme
+	}
+	if override.Locale != "" {
+		merged.Locale = override.Locale
+	}
+	if override.Location != nil {
+		merged.Location = override.Location
+	}
+	if override.ClassPrefix != "" {
+		merged.ClassPrefix = override.ClassPrefix
+	}
+	if override.Direction != "" {
+		merged.Direction = override.Direction
+	}
+	merged.Messages = mergeMessages(p.Messages, override.Messages)
+	if override.DefaultClass != nil {
+		merged.DefaultClass = override.DefaultClass
+	}
+	if override.DefaultStyle != nil {
+		merged.DefaultStyle = override.DefaultStyle
+	}
+	if override.URLPolicy != nil {
+		merged.URLPolicy = override.URLPolicy
+	}
+	if override.Collator != nil {
+		merged.Collator = override.Collator
+	}
+	return merged
+}
+
+// Apply fills the presentation fields opts leaves unset from the profile;
+// values set on opts win, with the same rules as Merge.
+func (p Profile) Apply(opts Options) Options {
+	merged := p.Merge(Profile{
+		Theme:        opts.Theme,
+		Locale:       opts.Locale,
+		Location:     opts.Location,
+		ClassPrefix:  opts.ClassPrefix,
+		Direction:    opts.Direction,
+		Messages:     opts.Messages,
+		DefaultClass: opts.DefaultClass,
+		DefaultStyle: opts.DefaultStyle,
+		URLPolicy:    opts.URLPolicy,
+		Collator:     opts.Collator,
+	})
+	opts.Theme = merged.Theme
+	opts.Locale = merged.Locale
+	opts.Location = merged.Location
+	opts.ClassPrefix = merged.ClassPrefix
+	opts.Direction = merged.Direction
+	opts.Messages = merged.Messages
+	opts.DefaultClass = merged.DefaultClass
+	opts.DefaultStyle = merged.DefaultStyle
+	opts.URLPolicy = merged.URLPolicy
+	opts.Collator = merged.Collator
+	return opts
+}
+
+// RenderWithProfile renders with opts completed by profile.Apply.
+func RenderWithProfile[T any](profile Profile, data []T, schema Schema[T], opts Options) (Result, error) {
+	return RenderTableHTML(data, schema, profile.Apply(opts))
+}
+
+func mergeMessages(base, override *Messages) *Messages {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+	merged := *base
+	if override.BooleanTrue != "" {
+		merged.BooleanTrue = override.BooleanTrue
+	}
+	if override.BooleanFalse != "" {
+		merged.BooleanFalse = override.BooleanFalse
+	}
+	if override.EmptyState != "" {
+		merged.EmptyState = override.EmptyState
+	}
+	if override.Submit != "" {
+		merged.Submit = override.Submit
+	}
+	return &merged
+}
+
+func (o *Options) presentationAttrs() []string {
+	var attrs []string
+	if o.Locale != "" {
+		attrs = append(attrs, "lang", o.Locale)
+	}
+	if o.Theme != "" {
+		attrs = append(attrs, "data-extable-theme", o.Theme)
+	}
+	return attrs
+}
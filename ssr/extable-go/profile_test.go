@@ -0,0 +1,48 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderWithProfile(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tenant := Profile{
+		Theme:       "dark",
+		Locale:      "ja-JP",
+		Location:    tokyo,
+		ClassPrefix: "acme-",
+		Messages:    &Messages{BooleanTrue: "はい", EmptyState: "データなし"},
+	}.Merge(Profile{Messages: &Messages{BooleanFalse: "いいえ"}})
+	if tenant.Messages.BooleanTrue != "はい" || tenant.Messages.BooleanFalse != "いいえ" {
+		t.Fatalf("expected messages to merge per field, got %+v", tenant.Messages)
+	}
+
+	type event struct {
+		At   time.Time `json:"at"`
+		Done bool      `json:"done"`
+	}
+	schema := Schema[event]{Columns: []Column[event]{
+		{Key: "at", Type: ColumnTypeDateTime},
+		{Key: "done", Type: ColumnTypeBoolean},
+	}}
+	rows := []event{{At: time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC), Done: true}}
+	result, err := RenderWithProfile(tenant, rows, schema, Options{Theme: "light"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{
+		`<table lang="ja-JP" data-extable-theme="light">`,
+		`class="acme-row-header"`,
+		`class="acme-cell cell-nowrap align-left acme-editable" data-col-key="at">2024-01-02 00:00:00</td>`,
+		`はい`,
+	} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s in %s", want, result.HTML)
+		}
+	}
+	if strings.Contains(result.HTML, `"extable-`) || strings.Contains(result.HTML, " extable-") {
+		t.Fatalf("expected every class to be prefixed, got %s", result.HTML)
+	}
+}
@@ -0,0 +1,146 @@
+package extable
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Formatter converts a cell value into display text.
+type Formatter func(value any, format *Format) string
+
+// CellRenderer produces the markup placed inside a cell. text is the output of
+// the column type's Formatter, or the default formatting when it has none.
+type CellRenderer func(ctx CellContext, text string) SafeHTML
+
+type customColumnType struct {
+	renderer  CellRenderer
+	formatter Formatter
+}
+
+var (
+	columnTypesMu sync.RWMutex
+	columnTypes   = make(map[ColumnType]customColumnType)
+)
+
+var builtinColumnTypes = map[ColumnType]bool{
+	ColumnTypeString:    true,
+	ColumnTypeNumber:    true,
+	ColumnTypeInt:       true,
+	ColumnTypeUint:      true,
+	ColumnTypeBoolean:   true,
+	ColumnTypeDate:      true,
+	ColumnTypeTime:      true,
+	ColumnTypeDateTime:  true,
+	ColumnTypeEnum:      true,
+	ColumnTypeEnumSet:   true,
+	ColumnTypeTags:      true,
+	ColumnTypeButton:    true,
+	ColumnTypeLink:      true,
+	ColumnTypeImage:     true,
+	ColumnTypeRichText:  true,
+	ColumnTypeSparkline: true,
+	ColumnTypeFile:      true,
+	ColumnTypeActions:   true,
+	ColumnTypePeriod:    true,
+	ColumnTypeGeo:       true,
+	ColumnTypeIP:        true,
+	ColumnTypeSemver:    true,
+	ColumnTypeTable:     true,
+}
+
+// RegisterColumnType makes name usable as a Column.Type, including in schemas
+// parsed from JSON. Either renderer or formatter may be nil. It panics if name
+// is empty, built in, or already registered.
+func RegisterColumnType(name string, renderer CellRenderer, formatter Formatter) {
+	colType := ColumnType(name)
+	if name == "" {
+		panic("extable: RegisterColumnType called with empty name")
+	}
+	if builtinColumnTypes[colType] {
+		panic("extable: RegisterColumnType cannot override built-in type " + name)
+	}
+	columnTypesMu.Lock()
+	defer columnTypesMu.Unlock()
+	if _, exists := columnTypes[colType]; exists {
+		panic("extable: RegisterColumnType called twice for " + name)
+	}
+	columnTypes[colType] = customColumnType{renderer: renderer, formatter: formatter}
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[string]Formatter)
+)
+
+// RegisterFormat makes fn available to columns whose Format.Custom is name.
+// It panics if name is empty or already registered.
+func RegisterFormat(name string, fn Formatter) {
+	if name == "" || fn == nil {
+		panic("extable: RegisterFormat requires a name and function")
+	}
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if _, exists := formats[name]; exists {
+		panic("extable: RegisterFormat called twice for " + name)
+	}
+	formats[name] = fn
+}
+
+func lookupFormat(name string) (Formatter, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	fn, ok := formats[name]
+	return fn, ok
+}
+
+func checkCustomFormats[T any](columns []Column[T]) error {
+	for _, col := range columns {
+		if col.Format == nil || col.Format.Custom == "" {
+			continue
+		}
+		if _, ok := lookupFormat(col.Format.Custom); !ok {
+			return &SchemaError{ColKey: col.Key, Message: fmt.Sprintf("uses unregistered format %q", col.Format.Custom)}
+		}
+	}
+	return nil
+}
+
+func lookupColumnType(colType ColumnType) (customColumnType, bool) {
+	columnTypesMu.RLock()
+	defer columnTypesMu.RUnlock()
+	custom, ok := columnTypes[colType]
+	return custom, ok
+}
+
+func isKnownColumnType(colType ColumnType) bool {
+	if builtinColumnTypes[colType] {
+		return true
+	}
+	_, ok := lookupColumnType(colType)
+	return ok
+}
+
+// ParseSchemaJSON decodes a schema and checks every column type and custom
+// format is built in or registered. Function-valued fields such as Formula stay nil.
+func ParseSchemaJSON[T any](data []byte) (Schema[T], error) {
+	var schema Schema[T]
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return Schema[T]{}, err
+	}
+	for _, col := range schema.Columns {
+		if col.Key == "" {
+			return Schema[T]{}, ErrMissingColumnKey
+		}
+		if !isKnownColumnType(col.Type) {
+			return Schema[T]{}, &SchemaError{ColKey: col.Key, Message: fmt.Sprintf("has unknown type %q", col.Type)}
+		}
+	}
+	if err := checkCustomFormats(schema.Columns); err != nil {
+		return Schema[T]{}, err
+	}
+	if err := checkExpressions(schema.Columns); err != nil {
+		return Schema[T]{}, err
+	}
+	return schema, nil
+}
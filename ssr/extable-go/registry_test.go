@@ -0,0 +1,73 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+type skuRow struct {
+	SKU string `json:"sku"`
+}
+
+func init() {
+	RegisterColumnType("sku",
+		func(ctx CellContext, text string) SafeHTML {
+			return SafeHTML(`<code class="sku">` + escapeHTML(text) + `</code>`)
+		},
+		func(value any, format *Format) string {
+			return strings.ToUpper(value.(string))
+		},
+	)
+}
+
+func TestRegisteredColumnTypeFromJSON(t *testing.T) {
+	schema, err := ParseSchemaJSON[skuRow]([]byte(`{"columns":[{"key":"sku","type":"sku","header":"SKU","readonly":true}]}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	result, err := RenderTableHTML([]skuRow{{SKU: "ab-<1>"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<code class="sku">AB-&lt;1&gt;</code>`) {
+		t.Fatalf("expected custom renderer output: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, "extable-readonly") {
+		t.Fatalf("expected readonly from JSON schema")
+	}
+}
+
+func TestParseSchemaJSONUnknownType(t *testing.T) {
+	if _, err := ParseSchemaJSON[skuRow]([]byte(`{"columns":[{"key":"sku","type":"geo-unknown"}]}`)); err == nil {
+		t.Fatalf("expected unknown type error")
+	}
+}
+
+func TestRegisterColumnTypeRejectsBuiltin(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	RegisterColumnType("string", nil, nil)
+}
+
+func TestCustomFormatByName(t *testing.T) {
+	RegisterFormat("test-years", func(value any, format *Format) string {
+		return formatInteger(value) + " yrs"
+	})
+	schema, err := ParseSchemaJSON[sampleRow]([]byte(`{"columns":[{"key":"age","type":"int","format":{"custom":"test-years"}}]}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	result, err := RenderTableHTML([]sampleRow{{Age: 30}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, ">30 yrs</td>") {
+		t.Fatalf("expected custom format: %s", result.HTML)
+	}
+	if _, err := ParseSchemaJSON[sampleRow]([]byte(`{"columns":[{"key":"age","type":"int","format":{"custom":"missing"}}]}`)); err == nil {
+		t.Fatalf("expected unregistered format error")
+	}
+}
@@ -1,134 +1,875 @@
 package extable
 
 import (
-	"errors"
-	"fmt"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 func RenderTableHTML[T any](data []T, schema Schema[T], opts Options) (Result, error) {
+	schema = schema.withDynamicColumns(data)
+	schemaHash := func() string { return SchemaHash(schema) }
+	return renderResult(opts, schemaHash, func(builder *htmlBuilder) (Metadata, error) {
+		return renderTable(builder, data, schema, opts)
+	})
+}
+
+// renderResult wraps a table render with the root wrapper, checksum and metrics.
+func renderResult(opts Options, schemaHash func() string, render func(builder *htmlBuilder) (Metadata, error)) (Result, error) {
+	var stats *statsCollector
+	if opts.CollectStats {
+		stats = startStats()
+	}
+	start := time.Now()
+	builder := newHTMLBuilder(opts.Indent, 0)
+	builder.classPrefix = opts.ClassPrefix
+	if opts.WrapWithRoot {
+		openRoot(builder, opts, schemaHash())
+	}
+	metadata, err := render(builder)
+	if err != nil {
+		return Result{}, err
+	}
+	if opts.WrapWithRoot {
+		closeRoot(builder)
+	}
+	html := builder.string()
+	metadata.Checksum = checksum(html, opts.ChecksumIgnoreAttrs)
+	if stats != nil {
+		metadata.Stats = stats.stop()
+	}
+	if opts.Metrics != nil {
+		opts.Metrics.ObserveRender(RenderStats{
+			Duration: time.Since(start),
+			Rows:     metadata.RowCount,
+			Bytes:    len(html),
+			Warnings: len(metadata.Warnings),
+		})
+	}
+	return Result{HTML: html, Metadata: metadata}, nil
+}
+
+func openRoot(builder *htmlBuilder, opts Options, schemaHash string) {
+	rootClass := append([]string{"extable-root"}, opts.DefaultClass...)
+	rootAttrs := []string{"class", strings.Join(rootClass, " ")}
+	if len(opts.DefaultStyle) > 0 {
+		rootAttrs = append(rootAttrs, "style", styleString(opts.DefaultStyle))
+	}
+	if opts.Direction != "" {
+		rootAttrs = append(rootAttrs, "dir", string(opts.Direction))
+	}
+	rootAttrs = append(rootAttrs, opts.presentationAttrs()...)
+	rootAttrs = append(rootAttrs, "data-extable-ssr-version", Version)
+	if schemaHash != "" {
+		rootAttrs = append(rootAttrs, "data-schema-hash", schemaHash)
+	}
+	builder.openTag("div", rootAttrs...)
+	builder.openTag("div", "class", "extable-shell")
+	builder.openTag("div", "class", "extable-viewport")
+}
+
+func closeRoot(builder *htmlBuilder) {
+	builder.closeTag("div")
+	builder.openTag("div", "class", "extable-overlay-layer")
+	builder.closeTag("div")
+	builder.closeTag("div")
+	builder.closeTag("div")
+}
+
+type tableRenderer[T any] struct {
+	opts              *Options
+	columns           []Column[T]
+	getter            *fieldGetter
+	ranges            []numericRange
+	hrefs             []*hrefTemplate
+	detail            func(T) SafeHTML
+	rowID             func(T) string
+	rowReadonlyReason func(T) string
+	rowFilter         func(T) bool
+	warnings          []Warning
+	truncated         int
+	hashes            []string
+	cacheHits         int
+	// position counts visible data rows written so far, for keyboard
+	// navigation; band counts them since the last group header, for striping.
+	position int
+	band     int
+	widths   []int
+	deadline time.Time
+	timedOut bool
+	groups   *groupState
+	dirty    map[CellRef]bool
+	// rowReason is the RowReadonlyReason of the row being written.
+	rowReason string
+}
+
+func renderTable[T any](builder *htmlBuilder, data []T, schema Schema[T], opts Options) (Metadata, error) {
+	r, data, nodes, err := prepareTable(data, schema, &opts)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return r.renderTable(builder, data, nodes)
+}
+
+func (r *tableRenderer[T]) renderTable(builder *htmlBuilder, data []T, nodes []treeNode) (Metadata, error) {
+	opts := r.opts
+	var tableAttrs []string
+	if !opts.WrapWithRoot {
+		if opts.Direction != "" {
+			tableAttrs = append(tableAttrs, "dir", string(opts.Direction))
+		}
+		tableAttrs = append(tableAttrs, opts.presentationAttrs()...)
+	}
+	if opts.CSRF != nil {
+		tableAttrs = append(tableAttrs, "data-csrf-field", opts.CSRF.fieldName(), "data-csrf-token", opts.CSRF.Token)
+	}
+	if opts.Responsive != nil {
+		tableAttrs = append(tableAttrs, "class", "extable-responsive-"+string(opts.Responsive.Mode), "data-responsive", string(opts.Responsive.Mode))
+		if opts.Responsive.Breakpoint > 0 {
+			tableAttrs = append(tableAttrs, "data-breakpoint", strconv.Itoa(opts.Responsive.Breakpoint))
+		}
+	}
+	if opts.FormMode != nil {
+		r.openForm(builder)
+	}
+	builder.openTag("table", tableAttrs...)
+	if opts.Caption != "" {
+		builder.openTag("caption", "class", "extable-caption")
+		builder.text(opts.Caption)
+		builder.closeTag("caption")
+	}
+	r.renderHead(builder)
+	total, rendered := r.renderBody(builder, data, nodes)
+	builder.closeTag("table")
+	if opts.FormMode != nil {
+		r.closeForm(builder)
+	}
+
+	if opts.EmitState {
+		if err := renderState(builder, r.columns); err != nil {
+			return Metadata{}, err
+		}
+	}
+	return r.metadata(builder, len(data), total, rendered), nil
+}
+
+// prepareTable resolves the schema against T and orders the rows, returning
+// the renderer shared by full-table, head-only and body-only output.
+func prepareTable[T any](data []T, schema Schema[T], opts *Options) (*tableRenderer[T], []T, []treeNode, error) {
+	if err := schema.ValidateOptions(*opts); err != nil {
+		return nil, nil, nil, err
+	}
+	compiled, err := compileSchema(schema.withDynamicColumns(data))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return compiled.prepare(data, opts)
+}
+
+// compiledSchema holds the work that depends only on the schema: field
+// resolution, template and expression checks, and parsed href templates.
+type compiledSchema[T any] struct {
+	schema Schema[T]
+	getter *fieldGetter
+	hrefs  []*hrefTemplate
+}
+
+// withDynamicColumns returns schema with Columns extended by DynamicColumns for data.
+func (s Schema[T]) withDynamicColumns(data []T) Schema[T] {
+	if s.DynamicColumns == nil {
+		return s
+	}
+	columns := append([]Column[T](nil), s.Columns...)
+	s.Columns = append(columns, s.DynamicColumns(data)...)
+	s.DynamicColumns = nil
+	return s
+}
+
+// keepRow reports whether row passes Schema.RowFilter.
+func keepRow[T any](filter func(T) bool, row T) bool {
+	return filter == nil || filter(row)
+}
+
+func compileSchema[T any](schema Schema[T]) (*compiledSchema[T], error) {
 	columns := schema.Columns
 	getter, err := newFieldGetter[T]()
 	if err != nil {
-		return Result{}, err
+		return nil, err
+	}
+	if err := checkCustomFormats(columns); err != nil {
+		return nil, err
+	}
+	if err := checkDateStyles(columns); err != nil {
+		return nil, err
+	}
+	if err := checkPeriods(columns); err != nil {
+		return nil, err
+	}
+	if err := checkGeoSpecs(columns); err != nil {
+		return nil, err
+	}
+	if err := checkTableSpecs(columns); err != nil {
+		return nil, err
+	}
+	if err := checkTextTemplates(columns); err != nil {
+		return nil, err
+	}
+	if err := checkExpressions(columns); err != nil {
+		return nil, err
+	}
+	hrefs, err := compileHrefTemplates(columns)
+	if err != nil {
+		return nil, err
 	}
+	return &compiledSchema[T]{schema: schema, getter: getter, hrefs: hrefs}, nil
+}
 
-	builder := &htmlBuilder{}
+// prepare sorts and flattens data and returns a renderer for it.
+func (c *compiledSchema[T]) prepare(data []T, opts *Options) (*tableRenderer[T], []T, []treeNode, error) {
+	c = c.visible()
+	if opts.PreviousValues != nil && c.schema.RowID == nil {
+		return nil, nil, nil, ErrMissingRowID
+	}
+	traceSchema(opts, c.schema.Columns, c.getter)
+	data, err := sortRows(data, c.schema.Columns, c.getter, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var nodes []treeNode
+	if c.schema.Children != nil {
+		data, nodes, err = flattenTree(data, c.schema, c.getter, opts)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	var groups *groupState
+	if opts.GroupBy != "" {
+		data, groups, err = c.groupRows(data, opts)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	r := c.newTableRenderer(data, opts)
+	r.groups = groups
+	return r, data, nodes, nil
+}
 
-	if opts.WrapWithRoot {
-		rootClass := append([]string{"extable-root"}, opts.DefaultClass...)
-		rootAttrs := []string{"class", strings.Join(rootClass, " ")}
-		if len(opts.DefaultStyle) > 0 {
-			rootAttrs = append(rootAttrs, "style", styleString(opts.DefaultStyle))
+// renderBody writes the tbody and returns how many rows passed
+// Schema.RowFilter and how many were rendered.
+func (r *tableRenderer[T]) renderBody(builder *htmlBuilder, data []T, nodes []treeNode) (int, int) {
+	builder.openTag("tbody")
+	total, rendered := 0, 0
+	for i, row := range data {
+		if r.expired() {
+			break
+		}
+		if !keepRow(r.rowFilter, row) {
+			continue
+		}
+		rowIndex := total
+		total++
+		if rowIndex < r.opts.Offset || (r.opts.Limit > 0 && rendered >= r.opts.Limit) {
+			continue
 		}
-		builder.openTag("div", rootAttrs...)
-		builder.openTag("div", "class", "extable-shell")
-		builder.openTag("div", "class", "extable-viewport")
+		var node *treeNode
+		if nodes != nil {
+			node = &nodes[i]
+		}
+		if r.groups != nil {
+			r.renderGroupHeader(builder, i)
+		}
+		r.renderRow(builder, rowIndex, row, node)
+		rendered++
+	}
+	if rendered == 0 && !r.timedOut {
+		r.renderEmptyState(builder)
+	}
+	if r.opts.NewRowPlaceholder {
+		r.renderNewRow(builder)
+	}
+	builder.closeTag("tbody")
+	return total, rendered
+}
+
+func (r *tableRenderer[T]) metadata(builder *htmlBuilder, rows, total, rendered int) Metadata {
+	for _, problem := range builder.attrProblems {
+		r.warn(-1, "", problem)
 	}
+	if !r.opts.WrapWithRoot && (len(r.opts.DefaultClass) > 0 || len(r.opts.DefaultStyle) > 0) {
+		r.warn(-1, "", "DefaultClass and DefaultStyle apply to the root wrapper and are ignored without WrapWithRoot")
+	}
+	return Metadata{
+		RowCount:       rendered,
+		ColumnCount:    len(r.columns),
+		Warnings:       r.warnings,
+		TruncatedCount: r.truncated,
+		FilteredCount:  rows - total,
+		TotalRows:      total,
+		RowHashes:      r.hashes,
+		CacheHits:      r.cacheHits,
+		ColumnWidths:   r.widths,
+		TimedOut:       r.timedOut,
+	}
+}
 
-	builder.openTag("table")
+func (c *compiledSchema[T]) newTableRenderer(data []T, opts *Options) *tableRenderer[T] {
+	columns := c.schema.Columns
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+	return &tableRenderer[T]{
+		deadline:          deadline,
+		dirty:             dirtySet(opts.DirtyCells),
+		opts:              opts,
+		columns:           columns,
+		getter:            c.getter,
+		ranges:            columnRanges(data, columns, c.getter, c.schema.RowFilter),
+		hrefs:             c.hrefs,
+		detail:            c.schema.DetailHTML,
+		rowID:             c.schema.RowID,
+		rowFilter:         c.schema.RowFilter,
+		rowReadonlyReason: c.schema.RowReadonlyReason,
+		widths:            headerWidths(columns),
+		warnings:          make([]Warning, 0),
+	}
+}
+
+func (r *tableRenderer[T]) warn(rowIndex int, colKey string, message string) {
+	r.opts.debug("extable: warning", "row", rowIndex, "col", colKey, "message", message)
+	r.warnings = append(r.warnings, Warning{RowIndex: rowIndex, ColKey: colKey, Message: message})
+}
+
+func (r *tableRenderer[T]) renderHead(builder *htmlBuilder) {
 	builder.openTag("thead")
 	builder.openTag("tr")
-	builder.openTag("th", "class", "extable-row-header extable-corner", "data-col-key", "")
+	headRows := 1
+	if hasHeaderGroups(r.columns) {
+		headRows = 2
+	}
+	cornerClasses := append([]string{"extable-row-header", "extable-corner"}, stickyClasses(r.opts, 0, true)...)
+	cornerAttrs := []string{"class", strings.Join(cornerClasses, " "), "data-col-key", ""}
+	if headRows > 1 {
+		cornerAttrs = append(cornerAttrs, "rowspan", strconv.Itoa(headRows))
+	}
+	if style := stickyStyle(r.opts, 0, true); style != nil {
+		cornerAttrs = append(cornerAttrs, "style", styleString(style))
+	}
+	builder.openTag("th", cornerAttrs...)
 	builder.closeTag("th")
-	for _, col := range columns {
-		builder.openTag("th", "data-col-key", col.Key)
+	colRow := headRows - 1
+	if headRows > 1 {
+		r.renderHeaderGroups(builder)
+		builder.closeTag("tr")
+		builder.openTag("tr")
+	}
+	for _, col := range r.columns {
+		thAttrs := []string{"data-col-key", col.Key, "scope", "col"}
+		thClasses := stickyClasses(r.opts, colRow, false)
+		if col.Priority > 0 {
+			thClasses = append(thClasses, priorityClass(col.Priority))
+			thAttrs = append(thAttrs, "data-priority", strconv.Itoa(col.Priority))
+		}
+		if len(thClasses) > 0 {
+			thAttrs = append(thAttrs, "class", strings.Join(thClasses, " "))
+		}
+		if style := stickyStyle(r.opts, colRow, false); style != nil {
+			thAttrs = append(thAttrs, "style", styleString(style))
+		}
+		sortDir := r.opts.sortDirection(col.Key)
+		if sortDir != "" {
+			ariaSort := "ascending"
+			if sortDir == "desc" {
+				ariaSort = "descending"
+			}
+			thAttrs = append(thAttrs, "data-extable-sort-dir", sortDir, "aria-sort", ariaSort)
+		}
+		descID := ""
+		if col.Description != "" {
+			descID = r.opts.idPrefix() + "-desc-" + col.Key
+			thAttrs = append(thAttrs, "aria-describedby", descID)
+		}
+		thAttrs = append(thAttrs, mapAttrs(col.HeaderAttrs)...)
+		builder.openTag("th", thAttrs...)
 		builder.openTag("div", "class", "extable-col-header")
+		if col.HeaderIcon != "" {
+			builder.openTag("span", "class", "extable-col-header-icon")
+			builder.raw(string(col.HeaderIcon))
+			builder.closeTag("span")
+		}
 		builder.openTag("span", "class", "extable-col-header-text")
-		builder.text(columnHeader(col))
+		if col.Description != "" {
+			builder.openTag("abbr", "title", col.Description)
+			builder.text(columnHeader(col))
+			builder.closeTag("abbr")
+		} else {
+			builder.text(columnHeader(col))
+		}
 		builder.closeTag("span")
+		if descID != "" {
+			builder.openTag("span", "id", descID, "class", "extable-col-description", "hidden", "")
+			builder.text(col.Description)
+			builder.closeTag("span")
+		}
+		if sortDir != "" {
+			renderSortIndicator(builder, sortDir)
+		}
 		builder.closeTag("div")
 		builder.closeTag("th")
 	}
 	builder.closeTag("tr")
 	builder.closeTag("thead")
-	builder.openTag("tbody")
+}
 
-	warnings := make([]Warning, 0)
-	for rowIndex, row := range data {
-		builder.openTag("tr")
-		builder.openTag("th", "class", "extable-row-header", "scope", "row")
-		builder.text(strconv.Itoa(rowIndex + 1))
-		builder.closeTag("th")
+func (r *tableRenderer[T]) renderEmptyState(builder *htmlBuilder) {
+	empty := r.opts.messages().EmptyState
+	if empty == "" {
+		return
+	}
+	builder.openTag("tr", "class", "extable-empty")
+	builder.openTag("td", "class", "extable-empty-state", "colspan", strconv.Itoa(len(r.columns)+1))
+	builder.text(empty)
+	builder.closeTag("td")
+	builder.closeTag("tr")
+}
 
-		rowReadonly := getter.rowReadonly(row)
+func (r *tableRenderer[T]) renderRow(builder *htmlBuilder, rowIndex int, row T, node *treeNode) {
+	if r.opts.rowCache != nil {
+		r.renderCachedRow(builder, rowIndex, row, node)
+	} else {
+		r.renderRowMarkup(builder, rowIndex, row, node)
+	}
+	if !r.rowHidden() {
+		r.position++
+		r.band++
+	}
+}
 
-		for _, col := range columns {
-			value, ok := getter.valueForKey(row, col.Key)
-			if col.Formula != nil && !ok {
-				warnings = append(warnings, Warning{
-					RowIndex: rowIndex,
-					ColKey:   col.Key,
-					Message:  "formula value missing",
-				})
-			}
+func (r *tableRenderer[T]) renderRowMarkup(builder *htmlBuilder, rowIndex int, row T, node *treeNode) {
+	hooks := r.opts.Hooks
+	rowCtx := RowContext{RowIndex: rowIndex, Row: row, ColumnCount: len(r.columns) + 1}
+	if hooks != nil && hooks.BeforeRow != nil {
+		builder.rawBlock(string(hooks.BeforeRow(rowCtx)))
+	}
+	var trClasses, trAttrs []string
+	if node != nil {
+		trClasses = append(trClasses, treeRowClasses(*node)...)
+		trAttrs = append(trAttrs, "data-depth", strconv.Itoa(node.depth))
+	}
+	if class := r.stripeClass(); class != "" {
+		trClasses = append(trClasses, class)
+	}
+	if len(trClasses) > 0 {
+		trAttrs = append([]string{"class", joinClasses(trClasses)}, trAttrs...)
+	}
+	if r.opts.EmitRowHash {
+		hash := r.rowHash(row)
+		r.hashes = append(r.hashes, hash)
+		trAttrs = append(trAttrs, "data-row-hash", hash)
+	}
+	if r.rowHidden() {
+		trAttrs = append(trAttrs, "hidden", "")
+	}
+	if r.opts.RowHeight > 0 {
+		height := strconv.Itoa(r.opts.RowHeight)
+		trAttrs = append(trAttrs, "data-row-height", height, "style", "height: "+height+"px;")
+	}
+	builder.openTag("tr", trAttrs...)
+	rowHeaderClasses := append([]string{"extable-row-header"}, stickyClasses(r.opts, -1, true)...)
+	rowHeaderAttrs := []string{"class", strings.Join(rowHeaderClasses, " "), "scope", "row"}
+	if style := stickyStyle(r.opts, -1, true); style != nil {
+		rowHeaderAttrs = append(rowHeaderAttrs, "style", styleString(style))
+	}
+	builder.openTag("th", rowHeaderAttrs...)
+	builder.text(strconv.Itoa(rowIndex + 1))
+	builder.closeTag("th")
 
-			classes := []string{"extable-cell"}
-			if col.Type == ColumnTypeBoolean {
-				classes = append(classes, "extable-boolean")
+	rowReadonly := r.getter.rowReadonly(row)
+	r.rowReason = ""
+	if r.rowReadonlyReason != nil {
+		if r.rowReason = r.rowReadonlyReason(row); r.rowReason != "" {
+			rowReadonly = true
+		}
+	}
+	for colIndex := 0; colIndex < len(r.columns); {
+		colIndex += r.renderCell(builder, rowIndex, colIndex, row, rowReadonly, node)
+	}
+	builder.closeTag("tr")
+	if r.detail != nil {
+		r.renderDetail(builder, row)
+	}
+	if hooks != nil && hooks.AfterRow != nil {
+		builder.rawBlock(string(hooks.AfterRow(rowCtx)))
+	}
+}
+
+func (r *tableRenderer[T]) renderDetail(builder *htmlBuilder, row T) {
+	detail := r.detail(row)
+	if detail == "" {
+		return
+	}
+	builder.openTag("tr", "class", "extable-detail", "hidden", "")
+	builder.openTag("td", "class", "extable-detail-cell", "colspan", strconv.Itoa(len(r.columns)+1))
+	builder.raw(string(detail))
+	builder.closeTag("td")
+	builder.closeTag("tr")
+}
+
+// renderCell writes the cell at colIndex and returns how many columns it covered.
+func (r *tableRenderer[T]) renderCell(builder *htmlBuilder, rowIndex int, colIndex int, row T, rowReadonly bool, node *treeNode) int {
+	col := r.columns[colIndex]
+	value, ok := columnValue(r.getter, row, col)
+	if spanned, isSpan := value.(SpannedCell); isSpan {
+		return r.renderSpannedCell(builder, colIndex, spanned, node)
+	}
+	traceCoercion(r.opts, rowIndex, col, value)
+	r.observeWidth(colIndex, formatValue(value, col, r.opts))
+	if col.Expr != "" && !ok {
+		r.warn(rowIndex, col.Key, "expr evaluation failed")
+	} else if col.TextTemplate != "" && !ok {
+		r.warn(rowIndex, col.Key, "text template failed")
+	} else if col.Formula != nil && !col.Virtual && !ok {
+		r.warn(rowIndex, col.Key, "formula value missing")
+	} else if col.Type == ColumnTypeSemver && value != nil {
+		if _, valid := semverValue(value); !valid {
+			r.warn(rowIndex, col.Key, "invalid semantic version")
+		}
+	}
+
+	classes := []string{"extable-cell"}
+	if col.Type == ColumnTypeBoolean {
+		classes = append(classes, "extable-boolean")
+		if value == nil {
+			classes = append(classes, "extable-boolean-unknown")
+		}
+	}
+	if col.Type == ColumnTypeRichText {
+		classes = append(classes, "extable-richtext")
+	}
+	if col.WrapText {
+		classes = append(classes, "cell-wrap")
+	} else {
+		classes = append(classes, "cell-nowrap")
+	}
+	if isRightAligned(col.Type) != r.opts.isRTL() {
+		classes = append(classes, "align-right")
+	} else {
+		classes = append(classes, "align-left")
+	}
+	if col.Format != nil && col.Format.NegativeClass && isRightAligned(col.Type) && isNegative(value) {
+		classes = append(classes, "extable-negative")
+	}
+	classes = append(classes, r.cellClasses(rowIndex, col, row)...)
+	if col.Priority > 0 {
+		classes = append(classes, priorityClass(col.Priority))
+	}
+	if col.MaxLines > 0 {
+		classes = append(classes, "extable-line-clamp")
+	}
+	cellError, invalid := r.cellError(rowIndex, row, col)
+	if invalid {
+		classes = append(classes, "extable-invalid")
+	}
+	dirty := r.isDirty(rowIndex, row, col)
+	if dirty {
+		classes = append(classes, "extable-dirty")
+	}
+	editable := !(col.Readonly || col.derived() || rowReadonly || col.Type == ColumnTypeSparkline || col.Type == ColumnTypeTable)
+	if !editable {
+		classes = append(classes, "extable-readonly")
+		if col.Formula != nil {
+			classes = append(classes, "extable-readonly-formula")
+		}
+	} else {
+		classes = append(classes, "extable-editable")
+	}
+
+	style := make(map[string]string)
+	if col.Heatmap != nil {
+		if color, ok := heatmapColor(value, col.Heatmap, r.ranges[colIndex]); ok {
+			style["background-color"] = color
+		}
+	}
+
+	tdAttrs := []string{"class", joinClasses(classes), "data-col-key", col.Key}
+	if col.Priority > 0 {
+		tdAttrs = append(tdAttrs, "data-priority", strconv.Itoa(col.Priority))
+	}
+	if r.opts.Responsive != nil && r.opts.Responsive.Mode == ResponsiveStack {
+		tdAttrs = append(tdAttrs, "data-label", columnHeader(col))
+	}
+	if r.opts.EmitCellCoordinates {
+		tdAttrs = append(tdAttrs, "data-row-index", strconv.Itoa(rowIndex))
+		if r.rowID != nil {
+			tdAttrs = append(tdAttrs, "data-row-id", r.rowID(row))
+		}
+	}
+	if len(style) > 0 {
+		tdAttrs = append(tdAttrs, "style", styleString(style))
+	}
+	tdAttrs = append(tdAttrs, r.navAttrs(colIndex)...)
+	if col.MaxChars > 0 && col.Type == ColumnTypeString {
+		if full := formatValue(value, col, r.opts); utf8.RuneCountInString(full) > col.MaxChars {
+			tdAttrs = append(tdAttrs, "title", full, "data-full-value", full)
+			r.truncated++
+		}
+	}
+	if dirty {
+		tdAttrs = append(tdAttrs, "data-dirty", "")
+	}
+	if !editable {
+		if reason := r.readonlyReason(col, rowReadonly); reason != "" {
+			tdAttrs = append(tdAttrs, "data-readonly-reason", reason)
+			if !hasAttr(tdAttrs, "title") {
+				tdAttrs = append(tdAttrs, "title", reason)
 			}
-			if col.WrapText {
-				classes = append(classes, "cell-wrap")
-			} else {
-				classes = append(classes, "cell-nowrap")
+		}
+	}
+	if invalid {
+		tdAttrs = append(tdAttrs, "aria-invalid", "true", "data-error", cellError)
+		if !hasAttr(tdAttrs, "title") {
+			tdAttrs = append(tdAttrs, "title", cellError)
+		}
+	}
+	if key, ok := cellSortKey(col.Type, value); ok {
+		tdAttrs = append(tdAttrs, "data-value", key)
+	}
+	tdAttrs = append(tdAttrs, r.prevValueAttrs(row, col, value)...)
+	tdAttrs = append(tdAttrs, mapAttrs(col.CellAttrs)...)
+	builder.openTag("td", tdAttrs...)
+	if node != nil && colIndex == 0 {
+		renderTreeExpander(builder, *node)
+	}
+	hooks := r.opts.Hooks
+	cellCtx := CellContext{RowIndex: rowIndex, Row: row, ColKey: col.Key, Value: value}
+	if hooks != nil && hooks.BeforeCell != nil {
+		builder.raw(string(hooks.BeforeCell(cellCtx)))
+	}
+	if col.MaxLines > 0 {
+		builder.openTag("div", "class", "extable-clamp", "style", lineClampStyle(col.MaxLines))
+	}
+	if r.opts.FormMode != nil && editable && hasFormControl(col.Type) {
+		r.renderFormControl(builder, rowIndex, colIndex, row, value)
+	} else if custom, ok := lookupColumnType(col.Type); ok && custom.renderer != nil {
+		builder.raw(string(custom.renderer(cellCtx, formatValue(value, col, r.opts))))
+	} else {
+		r.renderCellContent(builder, rowIndex, colIndex, row, value)
+	}
+	if col.MaxLines > 0 {
+		builder.closeTag("div")
+	}
+	if invalid {
+		builder.openTag("span", "class", "extable-error-message")
+		builder.text(cellError)
+		builder.closeTag("span")
+	}
+	if hooks != nil && hooks.AfterCell != nil {
+		builder.raw(string(hooks.AfterCell(cellCtx)))
+	}
+	builder.closeTag("td")
+	return 1
+}
+
+func (r *tableRenderer[T]) renderCellContent(builder *htmlBuilder, rowIndex int, colIndex int, row T, value any) {
+	col := r.columns[colIndex]
+	text := formatValue(value, col, r.opts)
+	if col.DataBar != nil && isRightAligned(col.Type) {
+		renderDataBar(builder, value, text, col.DataBar, col.Format, r.ranges[colIndex])
+		return
+	}
+	switch col.Type {
+	case ColumnTypeButton:
+		buttonAttrs := []string{"class", "extable-action-button", "type", "button"}
+		if tmpl := r.hrefs[colIndex]; tmpl != nil {
+			if href, ok := r.expandHref(rowIndex, col.Key, tmpl, row); ok {
+				buttonAttrs = append(buttonAttrs, "data-href", href)
 			}
-			if isRightAligned(col.Type) {
-				classes = append(classes, "align-right")
-			} else {
-				classes = append(classes, "align-left")
+		}
+		builder.openTag("button", buttonAttrs...)
+		r.text(builder, text)
+		builder.closeTag("button")
+		if csrf := r.opts.CSRF; csrf != nil {
+			builder.voidTag("input", "type", "hidden", "name", csrf.fieldName(), "value", csrf.Token)
+		}
+	case ColumnTypeLink:
+		if tmpl := r.hrefs[colIndex]; tmpl != nil && value != nil {
+			href := tmpl.expand(r.getter, row)
+			value = LinkValue{Label: text, Href: href, Target: col.Link.Target}
+		}
+		r.renderLink(builder, rowIndex, col.Key, value, text)
+	case ColumnTypeImage:
+		r.renderImage(builder, rowIndex, col.Key, value)
+	case ColumnTypeFile:
+		r.renderFiles(builder, rowIndex, col.Key, value, text)
+	case ColumnTypeActions:
+		r.renderActions(builder, row, col)
+	case ColumnTypeGeo:
+		r.renderGeo(builder, rowIndex, col, value, text)
+	case ColumnTypeTable:
+		r.renderNestedTable(builder, rowIndex, col, value, text)
+	case ColumnTypeEnum:
+		if key, ok := value.(string); ok && col.Enum != nil && col.Enum.hasBadge(key) {
+			renderEnumBadge(builder, key, text, col.Enum)
+		} else {
+			r.text(builder, text)
+		}
+	case ColumnTypeEnumSet:
+		if values, ok := value.([]string); ok {
+			renderEnumSetChips(builder, values, col.Enum)
+		} else {
+			r.text(builder, text)
+		}
+	case ColumnTypeTags:
+		if tags, ok := value.([]string); ok {
+			renderTagChips(builder, tags, col.Tags)
+		} else {
+			r.text(builder, text)
+		}
+	case ColumnTypeRichText:
+		if value != nil {
+			builder.raw(r.opts.sanitizer().Sanitize(text))
+		}
+	case ColumnTypeSparkline:
+		if values, ok := sparklineValues(value); ok {
+			renderSparkline(builder, values, col.Sparkline)
+		}
+	default:
+		if isRightAligned(col.Type) {
+			renderNumberText(builder, text, col.Format)
+		} else if col.Type == ColumnTypeString {
+			r.text(builder, truncateText(text, col.MaxChars))
+		} else {
+			r.text(builder, text)
+		}
+	}
+}
+
+func (r *tableRenderer[T]) renderLink(builder *htmlBuilder, rowIndex int, colKey string, value any, text string) {
+	link, ok := linkValue(value)
+	if !ok || link.Href == "" {
+		builder.openTag("span", "class", "extable-action-link")
+		r.text(builder, text)
+		builder.closeTag("span")
+		return
+	}
+	label := link.Label
+	if label == "" {
+		label = link.Href
+	}
+	href, allowed := r.opts.urlPolicy().sanitize(link.Href)
+	if !allowed {
+		r.warn(rowIndex, colKey, "link href rejected by url policy")
+		builder.openTag("span", "class", "extable-action-link")
+		r.text(builder, label)
+		builder.closeTag("span")
+		return
+	}
+	attrs := []string{"class", "extable-action-link", "href", href}
+	if link.Target != "" {
+		attrs = append(attrs, "target", link.Target, "rel", "noopener noreferrer")
+	}
+	builder.openTag("a", attrs...)
+	r.text(builder, label)
+	builder.closeTag("a")
+}
+
+func (r *tableRenderer[T]) renderImage(builder *htmlBuilder, rowIndex int, colKey string, value any) {
+	image, ok := imageValue(value)
+	if !ok || image.Src == "" {
+		return
+	}
+	src, allowed := r.opts.urlPolicy().sanitize(image.Src)
+	if !allowed {
+		r.warn(rowIndex, colKey, "image src rejected by url policy")
+		return
+	}
+	builder.voidTag("img", "class", "extable-image", "src", src, "alt", image.Alt)
+}
+
+func renderTagChips(builder *htmlBuilder, tags []string, spec *TagsSpec) {
+	for _, tag := range tags {
+		classes := "extable-tag"
+		attrs := []string{}
+		if spec != nil {
+			if class := spec.Classes[tag]; class != "" {
+				classes += " " + class
 			}
-			if col.Readonly || col.Formula != nil || rowReadonly {
-				classes = append(classes, "extable-readonly")
-				if col.Formula != nil {
-					classes = append(classes, "extable-readonly-formula")
-				}
-			} else {
-				classes = append(classes, "extable-editable")
+			if color, ok := cssColor(spec.Colors[tag]); ok {
+				attrs = append(attrs, "style", styleString(map[string]string{"background-color": color}))
 			}
+		}
+		builder.openTag("span", append([]string{"class", classes, "data-tag", tag}, attrs...)...)
+		builder.text(tag)
+		builder.closeTag("span")
+	}
+}
 
-			builder.openTag("td", "class", strings.Join(classes, " "), "data-col-key", col.Key)
-
-			text := formatValue(value, col)
-			if col.Type == ColumnTypeButton {
-				builder.openTag("button", "class", "extable-action-button", "type", "button")
-				builder.text(text)
-				builder.closeTag("button")
-			} else if col.Type == ColumnTypeLink {
-				builder.openTag("span", "class", "extable-action-link")
-				builder.text(text)
-				builder.closeTag("span")
-			} else {
-				builder.text(text)
+func renderEnumSetChips(builder *htmlBuilder, values []string, spec *EnumSpec) {
+	for _, value := range values {
+		classes := "extable-tag extable-enum-chip"
+		label := value
+		attrs := []string{}
+		if spec != nil {
+			if found, ok := spec.Labels[value]; ok {
+				label = found
+			}
+			if class := spec.Classes[value]; class != "" {
+				classes += " " + class
+			}
+			if color, ok := cssColor(spec.Colors[value]); ok {
+				attrs = append(attrs, "style", styleString(map[string]string{"background-color": color}))
 			}
-
-			builder.closeTag("td")
 		}
-		builder.closeTag("tr")
+		builder.openTag("span", append([]string{"class", classes, "data-enum", value}, attrs...)...)
+		builder.text(label)
+		builder.closeTag("span")
 	}
+}
 
-	builder.closeTag("tbody")
-	builder.closeTag("table")
+func (e *EnumSpec) allowedValues() []string {
+	if len(e.Values) > 0 {
+		return e.Values
+	}
+	values := make([]string, 0, len(e.Labels))
+	for value := range e.Labels {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
 
-	if opts.WrapWithRoot {
-		builder.closeTag("div")
-		builder.openTag("div", "class", "extable-overlay-layer")
-		builder.closeTag("div")
-		builder.closeTag("div")
-		builder.closeTag("div")
+func (e *EnumSpec) hasBadge(value string) bool {
+	_, colored := cssColor(e.Colors[value])
+	return e.Classes[value] != "" || colored
+}
+
+func renderEnumBadge(builder *htmlBuilder, value string, label string, spec *EnumSpec) {
+	classes := "extable-enum-badge"
+	if class := spec.Classes[value]; class != "" {
+		classes += " " + class
 	}
+	attrs := []string{"class", classes, "data-enum", value}
+	if color, ok := cssColor(spec.Colors[value]); ok {
+		attrs = append(attrs, "style", styleString(map[string]string{"background-color": color}))
+	}
+	builder.openTag("span", attrs...)
+	builder.text(label)
+	builder.closeTag("span")
+}
+
+func truncateText(text string, maxChars int) string {
+	if maxChars <= 0 || utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:maxChars-1]) + "…"
+}
 
-	return Result{
-		HTML: builder.string(),
-		Metadata: Metadata{
-			RowCount:    len(data),
-			ColumnCount: len(columns),
-			Warnings:    warnings,
-		},
-	}, nil
+func priorityClass(priority int) string {
+	return "extable-priority-" + strconv.Itoa(priority)
 }
 
 func columnHeader[T any](col Column[T]) string {
@@ -142,10 +883,21 @@ func isRightAligned(colType ColumnType) bool {
 	return colType == ColumnTypeNumber || colType == ColumnTypeInt || colType == ColumnTypeUint
 }
 
-func formatValue[T any](value any, col Column[T]) string {
+func formatValue[T any](value any, col Column[T], opts *Options) string {
 	if value == nil {
+		if col.Type == ColumnTypeBoolean {
+			return booleanUnknownLabel(col.Format, opts.messages())
+		}
 		return ""
 	}
+	if col.Format != nil && col.Format.Custom != "" {
+		if fn, ok := lookupFormat(col.Format.Custom); ok {
+			return fn(value, col.Format)
+		}
+	}
+	if custom, ok := lookupColumnType(col.Type); ok && custom.formatter != nil {
+		return custom.formatter(value, col.Format)
+	}
 	if col.Type == ColumnTypeTags {
 		if tags, ok := value.([]string); ok {
 			sep := ", "
@@ -156,19 +908,86 @@ func formatValue[T any](value any, col Column[T]) string {
 		}
 	}
 
+	if col.Type == ColumnTypeEnumSet {
+		if values, ok := value.([]string); ok {
+			labels := make([]string, len(values))
+			for i, v := range values {
+				labels[i] = v
+				if col.Enum != nil {
+					if label, found := col.Enum.Labels[v]; found {
+						labels[i] = label
+					}
+				}
+			}
+			return strings.Join(labels, ", ")
+		}
+	}
+
 	switch col.Type {
 	case ColumnTypeBoolean:
-		return formatBoolean(value, col.Format)
+		return formatBoolean(value, col.Format, opts.messages())
 	case ColumnTypeNumber:
-		return formatNumber(value, col.Format)
+		return applyNegativeStyle(formatNumber(value, col.Format), col.Format)
 	case ColumnTypeInt, ColumnTypeUint:
-		return formatInteger(value)
+		return applyNegativeStyle(formatInteger(value), col.Format)
 	case ColumnTypeDate:
-		return formatTimeValue(value, defaultDateLayout(col.Format))
+		if col.Format != nil && col.Format.DateStyle != "" {
+			if t, ok := timeValue(value, col.Format); ok {
+				return formatDateStyle(t, col.Format.DateStyle, opts.Locale)
+			}
+		}
+		return formatTimeValue(value, defaultDateLayout(col.Format), col.Format)
 	case ColumnTypeTime:
-		return formatTimeValue(value, defaultTimeLayout(col.Format))
+		return formatTimeValue(value, defaultTimeLayout(col.Format), col.Format)
 	case ColumnTypeDateTime:
-		return formatTimeValue(value, defaultDateTimeLayout(col.Format))
+		if t, ok := timeValue(value, col.Format); ok && opts.Location != nil {
+			value = t.In(opts.Location)
+		}
+		if col.Format != nil && col.Format.DateStyle != "" {
+			if t, ok := timeValue(value, col.Format); ok {
+				layout := col.Format.TimeLayout
+				if layout == "" {
+					layout = "15:04"
+				}
+				return formatDateStyle(t, col.Format.DateStyle, opts.Locale) + " " + t.Format(layout)
+			}
+		}
+		return formatTimeValue(value, defaultDateTimeLayout(col.Format), col.Format)
+	case ColumnTypePeriod:
+		if t, ok := timeValue(value, col.Format); ok {
+			return formatPeriod(t, col.Period)
+		}
+	case ColumnTypeGeo:
+		if point, ok := geoValue(value); ok {
+			return formatGeo(point, col.Geo)
+		}
+	case ColumnTypeIP:
+		if prefix, ok := ipValue(value); ok {
+			return formatIP(prefix)
+		}
+	case ColumnTypeSemver:
+		if version, ok := semverValue(value); ok {
+			return version.String()
+		}
+	case ColumnTypeTable:
+		if text, ok := formatNestedTable(value, col.Table); ok {
+			return text
+		}
+	case ColumnTypeLink:
+		if link, ok := linkValue(value); ok {
+			if link.Label != "" {
+				return link.Label
+			}
+			return link.Href
+		}
+	case ColumnTypeImage:
+		if image, ok := imageValue(value); ok {
+			return image.Alt
+		}
+	case ColumnTypeFile:
+		if names, ok := formatFiles(value); ok {
+			return names
+		}
 	case ColumnTypeEnum:
 		if col.Enum != nil {
 			if s, ok := value.(string); ok {
@@ -178,27 +997,21 @@ func formatValue[T any](value any, col Column[T]) string {
 			}
 		}
 	}
-	return fmt.Sprint(value)
+	return stringifyValue(value)
 }
 
-func formatBoolean(value any, format *Format) string {
-	v, ok := value.(bool)
+func formatBoolean(value any, format *Format, messages Messages) string {
+	v, ok := booleanValue(value, format)
 	if !ok {
-		return fmt.Sprint(value)
-	}
-	if format == nil {
-		if v {
-			return "true"
-		}
-		return "false"
+		return stringifyValue(value)
 	}
-	trueLabel := format.BooleanTrue
-	falseLabel := format.BooleanFalse
-	if trueLabel == "" {
-		trueLabel = "true"
+	trueLabel := messages.BooleanTrue
+	falseLabel := messages.BooleanFalse
+	if format != nil && format.BooleanTrue != "" {
+		trueLabel = format.BooleanTrue
 	}
-	if falseLabel == "" {
-		falseLabel = "false"
+	if format != nil && format.BooleanFalse != "" {
+		falseLabel = format.BooleanFalse
 	}
 	if v {
 		return trueLabel
@@ -206,6 +1019,36 @@ func formatBoolean(value any, format *Format) string {
 	return falseLabel
 }
 
+func booleanUnknownLabel(format *Format, messages Messages) string {
+	if format != nil && format.BooleanUnknown != "" {
+		return format.BooleanUnknown
+	}
+	return messages.BooleanUnknown
+}
+
+func booleanValue(value any, format *Format) (bool, bool) {
+	if v, ok := value.(bool); ok {
+		return v, true
+	}
+	if format == nil || len(format.BooleanTruthy) == 0 {
+		return false, false
+	}
+	var text string
+	if s, ok := value.(string); ok {
+		text = strings.TrimSpace(s)
+	} else if n, ok := integerValue(value); ok {
+		text = strconv.FormatInt(n, 10)
+	} else {
+		return false, false
+	}
+	for _, truthy := range format.BooleanTruthy {
+		if strings.EqualFold(text, truthy) {
+			return true, true
+		}
+	}
+	return false, true
+}
+
 func formatNumber(value any, format *Format) string {
 	scale := -1
 	if format != nil && format.NumberScale != nil {
@@ -213,19 +1056,50 @@ func formatNumber(value any, format *Format) string {
 	}
 	switch v := value.(type) {
 	case float32:
-		return formatFloat(float64(v), scale)
+		return formatFloatNumber(float64(v), scale, format)
 	case float64:
-		return formatFloat(v, scale)
+		return formatFloatNumber(v, scale, format)
+	}
+	if n, ok := integerValue(value); ok {
+		if _, unsigned := value.(uint64); unsigned {
+			return formatFloatNumber(float64(value.(uint64)), scale, format)
+		}
+		return formatFloatNumber(float64(n), scale, format)
+	}
+	if text, ok := formatBigNumber(value, scale); ok {
+		return text
+	}
+	return stringifyValue(value)
+}
+
+func numericValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
 	case int:
-		return formatFloat(float64(v), scale)
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
 	case int64:
-		return formatFloat(float64(v), scale)
-	case uint64:
-		return formatFloat(float64(v), scale)
+		return float64(v), true
 	case uint:
-		return formatFloat(float64(v), scale)
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
 	default:
-		return fmt.Sprint(value)
+		return bigNumericValue(value)
 	}
 }
 
@@ -240,7 +1114,10 @@ func formatInteger(value any) string {
 	case uint64:
 		return strconv.FormatUint(v, 10)
 	default:
-		return fmt.Sprint(value)
+		if text, ok := formatBigInteger(value); ok {
+			return text
+		}
+		return stringifyValue(value)
 	}
 }
 
@@ -251,23 +1128,14 @@ func formatFloat(value float64, scale int) string {
 	return strconv.FormatFloat(value, 'f', scale, 64)
 }
 
-func formatTimeValue(value any, layout string) string {
-	switch v := value.(type) {
-	case time.Time:
-		return v.Format(layout)
-	case *time.Time:
-		if v == nil {
-			return ""
-		}
-		return v.Format(layout)
-	case string:
-		if t, err := time.Parse(time.RFC3339, v); err == nil {
-			return t.Format(layout)
-		}
-		return v
-	default:
-		return fmt.Sprint(value)
+func formatTimeValue(value any, layout string, format *Format) string {
+	if t, ok := timeValue(value, format); ok {
+		return t.Format(layout)
+	}
+	if s, ok := value.(string); ok {
+		return s
 	}
+	return stringifyValue(value)
 }
 
 func defaultDateLayout(format *Format) string {
@@ -294,19 +1162,23 @@ func defaultDateTimeLayout(format *Format) string {
 type fieldGetter struct {
 	keyToIndex map[string][]int
 	keyNames   map[string]bool
+	mapRows    bool
 }
 
 func newFieldGetter[T any]() (*fieldGetter, error) {
 	var zero T
 	typeValue := reflect.TypeOf(zero)
 	if typeValue == nil {
-		return nil, errors.New("ssr: row type is nil")
+		return nil, ErrNilRowType
+	}
+	if typeValue.Kind() == reflect.Map && typeValue.Key().Kind() == reflect.String {
+		return &fieldGetter{mapRows: true}, nil
 	}
 	if typeValue.Kind() == reflect.Ptr {
 		typeValue = typeValue.Elem()
 	}
 	if typeValue.Kind() != reflect.Struct {
-		return nil, errors.New("ssr: row type must be a struct or pointer to struct")
+		return nil, ErrNotStruct
 	}
 	keyToIndex := make(map[string][]int)
 	keyNames := make(map[string]bool)
@@ -334,7 +1206,14 @@ func newFieldGetter[T any]() (*fieldGetter, error) {
 	return &fieldGetter{keyToIndex: keyToIndex, keyNames: keyNames}, nil
 }
 
+func (g *fieldGetter) hasKey(key string) bool {
+	return g.mapRows || g.keyNames[key]
+}
+
 func (g *fieldGetter) valueForKey(row any, key string) (any, bool) {
+	if g.mapRows {
+		return mapValueForKey(row, key)
+	}
 	index, ok := g.keyToIndex[key]
 	if !ok {
 		return nil, false
@@ -356,7 +1235,59 @@ func (g *fieldGetter) valueForKey(row any, key string) (any, bool) {
 	if !fieldValue.IsValid() {
 		return nil, false
 	}
-	return fieldValue.Interface(), true
+	return normalizeValue(fieldValue.Interface()), true
+}
+
+// derived reports whether the column's value is computed rather than stored,
+// which makes it read-only and excludes it from exports and patches.
+func (c Column[T]) derived() bool {
+	return c.Formula != nil || c.Virtual || c.TextTemplate != "" || c.Expr != "" || c.Type == ColumnTypeActions
+}
+
+// columnValue reads the column's field, falling back to Formula when the row
+// has no such field. It reports false when neither yields a value.
+func columnValue[T any](g *fieldGetter, row T, col Column[T]) (any, bool) {
+	if col.Expr != "" {
+		value, ok := evalExpr(col.Expr, false, g.rowEnv(row))
+		if !ok {
+			return nil, false
+		}
+		value = normalizeValue(value)
+		return value, value != nil
+	}
+	if col.TextTemplate != "" {
+		text, ok := executeTextTemplate(col.TextTemplate, row)
+		if !ok {
+			return nil, false
+		}
+		return text, true
+	}
+	if !col.Virtual {
+		if value, ok := g.valueForKey(row, col.Key); ok {
+			return value, true
+		}
+	}
+	if col.Formula == nil {
+		return nil, false
+	}
+	value := normalizeValue(col.Formula(row))
+	return value, value != nil
+}
+
+func mapValueForKey(row any, key string) (any, bool) {
+	if m, ok := row.(map[string]any); ok {
+		value, found := m[key]
+		return normalizeValue(value), found
+	}
+	value := reflect.ValueOf(row)
+	if !value.IsValid() || value.Kind() != reflect.Map || value.IsNil() {
+		return nil, false
+	}
+	item := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+	if !item.IsValid() {
+		return nil, false
+	}
+	return normalizeValue(item.Interface()), true
 }
 
 func (g *fieldGetter) rowReadonly(row any) bool {
@@ -379,6 +1310,34 @@ func jsonTagKey(tag string) string {
 	return parts[0]
 }
 
+// lineClampStyle limits a block to lines lines; the wrapper is needed because
+// the -webkit-box display it relies on cannot be applied to the cell itself.
+func lineClampStyle(lines int) string {
+	return styleString(map[string]string{
+		"display":            "-webkit-box",
+		"-webkit-box-orient": "vertical",
+		"-webkit-line-clamp": strconv.Itoa(lines),
+		"overflow":           "hidden",
+	})
+}
+
+// mapAttrs flattens attrs into openTag pairs, ordered by name.
+func mapAttrs(attrs map[string]string) []string {
+	if len(attrs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		pairs = append(pairs, key, attrs[key])
+	}
+	return pairs
+}
+
 func styleString(style map[string]string) string {
 	keys := make([]string, 0, len(style))
 	for key := range style {
@@ -1,7 +1,12 @@
 package extable
 
 import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -122,3 +127,852 @@ func TestFormulaMissingWarning(t *testing.T) {
 		t.Fatalf("unexpected warning col: %s", result.Metadata.Warnings[0].ColKey)
 	}
 }
+
+type trendRow struct {
+	Trend []float64 `json:"trend"`
+}
+
+func TestRenderSparkline(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]trendRow{{Trend: []float64{1, 3, 2}}},
+		Schema[trendRow]{Columns: []Column[trendRow]{
+			{Key: "trend", Type: ColumnTypeSparkline},
+			{Key: "trend", Type: ColumnTypeSparkline, Sparkline: &SparklineSpec{Kind: SparklineBar, Width: 30, Height: 10}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<polyline points="0,20 40,0 80,10"`) {
+		t.Fatalf("expected line sparkline: %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "<rect ") != 3 {
+		t.Fatalf("expected bar sparkline")
+	}
+	if !strings.Contains(result.HTML, "extable-readonly") {
+		t.Fatalf("expected sparkline cells to be readonly")
+	}
+}
+
+func TestRenderSparklineNonFinite(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]trendRow{{Trend: []float64{1, math.NaN(), 3, math.Inf(1), 2}}},
+		Schema[trendRow]{Columns: []Column[trendRow]{
+			{Key: "trend", Type: ColumnTypeSparkline},
+			{Key: "trend", Type: ColumnTypeSparkline, Sparkline: &SparklineSpec{Kind: SparklineBar}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Contains(result.HTML, "NaN") || strings.Contains(result.HTML, "Inf") {
+		t.Fatalf("expected non-finite points to be skipped: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<polyline points="0,20 40,0 80,10"`) || strings.Count(result.HTML, "<rect ") != 3 {
+		t.Fatalf("expected finite points to be drawn: %s", result.HTML)
+	}
+}
+
+func TestRenderHeatmap(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]sampleRow{{Name: "a", Age: 10}, {Name: "b", Age: 20}, {Name: "c", Age: 30}},
+		Schema[sampleRow]{Columns: []Column[sampleRow]{
+			{Key: "age", Type: ColumnTypeInt, Heatmap: &HeatmapSpec{LowColor: "#000000", HighColor: "#ffffff"}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, color := range []string{"#000000", "#808080", "#ffffff"} {
+		if !strings.Contains(result.HTML, "style=\"background-color: "+color+";\"") {
+			t.Fatalf("expected heatmap color %s: %s", color, result.HTML)
+		}
+	}
+}
+
+func TestRenderHeatmapNonFinite(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]invoiceRow{{Price: 10}, {Price: math.NaN()}, {Price: 30}, {Price: math.Inf(1)}},
+		Schema[invoiceRow]{Columns: []Column[invoiceRow]{
+			{Key: "price", Type: ColumnTypeNumber, Heatmap: &HeatmapSpec{LowColor: "#000000", HighColor: "#ffffff"}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Count(result.HTML, "background-color") != 2 {
+		t.Fatalf("expected only finite values to be colored: %s", result.HTML)
+	}
+	for _, color := range []string{"#000000", "#ffffff"} {
+		if !strings.Contains(result.HTML, "background-color: "+color+";") {
+			t.Fatalf("expected range computed from finite values, missing %s: %s", color, result.HTML)
+		}
+	}
+}
+
+func TestRenderDataBar(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]sampleRow{{Name: "a", Age: 10}, {Name: "b", Age: 40}},
+		Schema[sampleRow]{Columns: []Column[sampleRow]{
+			{Key: "age", Type: ColumnTypeInt, DataBar: &DataBarSpec{Color: "#638ec6"}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<div class="extable-databar-fill" style="background-color: #638ec6; width: 25%;"></div><span class="extable-databar-value">10</span>`) {
+		t.Fatalf("expected proportional data bar: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, "width: 100%;") {
+		t.Fatalf("expected full-width bar for max value")
+	}
+}
+
+func TestRenderDataBarUnsafeInputs(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]invoiceRow{{Price: 10}, {Price: math.NaN()}, {Price: math.Inf(-1)}, {Price: 40}},
+		Schema[invoiceRow]{Columns: []Column[invoiceRow]{
+			{Key: "price", Type: ColumnTypeNumber, DataBar: &DataBarSpec{Color: "red;background-image:url(//evil.example)"}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Contains(result.HTML, "evil") || strings.Contains(result.HTML, "background-color") {
+		t.Fatalf("expected unsafe color to be dropped: %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "extable-databar-fill") != 2 || !strings.Contains(result.HTML, `style="width: 25%;"`) {
+		t.Fatalf("expected bars only for finite values, scaled to them: %s", result.HTML)
+	}
+	for _, color := range []string{"#638ec6", "rgb(99, 142, 198)", "hsl(214 45% 58% / 0.5)", "steelblue"} {
+		if got, ok := cssColor(color); !ok || got != color {
+			t.Fatalf("expected %q to be accepted", color)
+		}
+	}
+	for _, color := range []string{"red;color:blue", "url(x)", "expression(alert(1))", "rgb(1,2,3);x", ""} {
+		if _, ok := cssColor(color); ok {
+			t.Fatalf("expected %q to be rejected", color)
+		}
+	}
+}
+
+type taggedRow struct {
+	Tags []string `json:"tags"`
+}
+
+func TestRenderTagChips(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]taggedRow{{Tags: []string{"urgent", "<b>"}}},
+		Schema[taggedRow]{Columns: []Column[taggedRow]{
+			{Key: "tags", Type: ColumnTypeTags, Tags: &TagsSpec{
+				Classes: map[string]string{"urgent": "tag-red"},
+				Colors:  map[string]string{"urgent": "#f00", "<b>": "red;background:url(//evil.example)"},
+			}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<span class="extable-tag tag-red" data-tag="urgent" style="background-color: #f00;">urgent</span>`) {
+		t.Fatalf("expected styled tag chip: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<span class="extable-tag" data-tag="&lt;b&gt;">&lt;b&gt;</span>`) {
+		t.Fatalf("expected escaped plain chip: %s", result.HTML)
+	}
+}
+
+type statusRow struct {
+	Status string `json:"status"`
+}
+
+func TestRenderEnumBadges(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]statusRow{{Status: "active"}, {Status: "paused"}},
+		Schema[statusRow]{Columns: []Column[statusRow]{
+			{Key: "status", Type: ColumnTypeEnum, Enum: &EnumSpec{
+				Labels:  map[string]string{"active": "Active", "paused": "Paused"},
+				Classes: map[string]string{"active": "badge-green"},
+				Colors:  map[string]string{"active": "#0a0", "paused": "\"><script>"},
+			}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<span class="extable-enum-badge badge-green" data-enum="active" style="background-color: #0a0;">Active</span>`) {
+		t.Fatalf("expected enum badge: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `data-col-key="status">Paused</td>`) {
+		t.Fatalf("expected plain label for unstyled value")
+	}
+}
+
+type teamRow struct {
+	Teams []string `json:"teams"`
+}
+
+func TestRenderEnumSet(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]teamRow{{Teams: []string{"ops", "dev"}}},
+		Schema[teamRow]{Columns: []Column[teamRow]{
+			{Key: "teams", Type: ColumnTypeEnumSet, Enum: &EnumSpec{
+				Values: []string{"dev", "ops", "qa"},
+				Labels: map[string]string{"dev": "Development", "ops": "Operations"},
+				Colors: map[string]string{"dev": "url(//evil.example)", "ops": "teal"},
+			}},
+		}},
+		Options{EmitState: true},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<span class="extable-tag extable-enum-chip" data-enum="ops" style="background-color: teal;">Operations</span><span class="extable-tag extable-enum-chip" data-enum="dev">Development</span>`) {
+		t.Fatalf("expected enum chips: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<script type="application/json" class="extable-state">{"columns":[{"key":"teams","type":"enumset","allowedValues":["dev","ops","qa"]}]}</script>`) {
+		t.Fatalf("expected serialized state: %s", result.HTML)
+	}
+}
+
+type flagRow struct {
+	Done bool `json:"done"`
+}
+
+func TestRenderMessages(t *testing.T) {
+	schema := Schema[flagRow]{Columns: []Column[flagRow]{{Key: "done", Type: ColumnTypeBoolean}}}
+	opts := Options{Messages: &Messages{BooleanTrue: "はい", BooleanFalse: "いいえ", EmptyState: "データがありません"}}
+	result, err := RenderTableHTML([]flagRow{{Done: true}, {Done: false}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, ">はい</td>") || !strings.Contains(result.HTML, ">いいえ</td>") {
+		t.Fatalf("expected localized boolean labels: %s", result.HTML)
+	}
+	empty, err := RenderTableHTML([]flagRow{}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(empty.HTML, `<td class="extable-empty-state" colspan="2">データがありません</td>`) {
+		t.Fatalf("expected empty state row: %s", empty.HTML)
+	}
+}
+
+func TestRenderRTL(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Alice", Age: 30}}, schema, Options{WrapWithRoot: true, Direction: DirectionRTL})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<div class="extable-root" dir="rtl" `) {
+		t.Fatalf("expected dir on root: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `align-right extable-editable" data-col-key="name"`) {
+		t.Fatalf("expected text aligned right in rtl")
+	}
+	if !strings.Contains(result.HTML, `align-left extable-editable" data-col-key="age"`) {
+		t.Fatalf("expected numbers aligned left in rtl")
+	}
+	bare, err := RenderTableHTML([]sampleRow{}, schema, Options{Direction: DirectionRTL})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.HasPrefix(bare.HTML, `<table dir="rtl">`) {
+		t.Fatalf("expected dir on table without root")
+	}
+}
+
+func TestRenderIndent(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]sampleRow{{Name: "Alice", Age: 30}},
+		Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString, Header: "Name"}}},
+		Options{Indent: "  "},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	expected := `<table>
+  <thead>
+    <tr>
+      <th class="extable-row-header extable-corner" data-col-key=""></th>
+      <th data-col-key="name" scope="col">
+        <div class="extable-col-header"><span class="extable-col-header-text">Name</span></div>
+      </th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <th class="extable-row-header" scope="row">1</th>
+      <td class="extable-cell cell-nowrap align-left extable-editable" data-col-key="name">Alice</td>
+    </tr>
+  </tbody>
+</table>`
+	if result.HTML != expected {
+		t.Fatalf("unexpected indented output:\n%s", result.HTML)
+	}
+}
+
+func TestRenderHooks(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]sampleRow{{Name: "Alice", Age: 30}},
+		Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}},
+		Options{Hooks: &Hooks{
+			AfterRow: func(ctx RowContext) SafeHTML {
+				row := ctx.Row.(sampleRow)
+				return SafeHTML(`<tr class="detail"><td colspan="` + strconv.Itoa(ctx.ColumnCount) + `">` + escapeHTML(row.Name) + `</td></tr>`)
+			},
+			BeforeCell: func(ctx CellContext) SafeHTML {
+				return SafeHTML(`<i class="icon-` + ctx.ColKey + `"></i>`)
+			},
+		}},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `data-col-key="name"><i class="icon-name"></i>Alice</td></tr><tr class="detail"><td colspan="2">Alice</td></tr>`) {
+		t.Fatalf("expected hook markup: %s", result.HTML)
+	}
+}
+
+func TestBooleanTruthyCoercion(t *testing.T) {
+	type csvFlagRow struct {
+		Active string `json:"active"`
+		Paid   int    `json:"paid"`
+	}
+	format := &Format{BooleanTrue: "Y", BooleanFalse: "N", BooleanTruthy: []string{"yes", "1"}}
+	schema := Schema[csvFlagRow]{Columns: []Column[csvFlagRow]{
+		{Key: "active", Type: ColumnTypeBoolean, Format: format},
+		{Key: "paid", Type: ColumnTypeBoolean, Format: format},
+	}}
+	result, err := RenderTableHTML([]csvFlagRow{{Active: "YES", Paid: 0}, {Active: "no", Paid: 1}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	cells := regexp.MustCompile(`<td[^>]*data-col-key="(active|paid)">([^<]*)<`).FindAllStringSubmatch(result.HTML, -1)
+	got := make([]string, len(cells))
+	for i, cell := range cells {
+		got[i] = cell[2]
+	}
+	if strings.Join(got, ",") != "Y,N,N,Y" {
+		t.Fatalf("unexpected coerced values %v in %s", got, result.HTML)
+	}
+}
+
+func TestMaxCharsTruncation(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString, MaxChars: 5}}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Alexandria"}, {Name: "Bo"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `title="Alexandria" data-full-value="Alexandria">Alex…</td>`) {
+		t.Fatalf("expected truncated cell with full value, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `>Bo</td>`) {
+		t.Fatalf("expected short value untouched, got %s", result.HTML)
+	}
+	if result.Metadata.TruncatedCount != 1 {
+		t.Fatalf("expected one truncated cell, got %d", result.Metadata.TruncatedCount)
+	}
+}
+
+func TestHighlightTerm(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	opts := Options{Highlight: &Highlight{Term: "an", CaseInsensitive: true}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Ann & Dan"}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := `<mark class="extable-highlight">An</mark>n &amp; D<mark class="extable-highlight">an</mark>`
+	if !strings.Contains(result.HTML, want) {
+		t.Fatalf("expected highlighted matches, got %s", result.HTML)
+	}
+
+	opts.Highlight.CaseInsensitive = false
+	result, err = RenderTableHTML([]sampleRow{{Name: "Ann & Dan"}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Count(result.HTML, "<mark") != 1 {
+		t.Fatalf("expected one case-sensitive match, got %s", result.HTML)
+	}
+}
+
+func TestRowFilter(t *testing.T) {
+	schema := Schema[sampleRow]{
+		Columns:   []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}},
+		RowFilter: func(row sampleRow) bool { return row.Age >= 18 },
+	}
+	data := []sampleRow{{Name: "Kid", Age: 9}, {Name: "Adult", Age: 30}, {Name: "Teen", Age: 15}}
+	result, err := RenderTableHTML(data, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Contains(result.HTML, "Kid") || strings.Contains(result.HTML, "Teen") || !strings.Contains(result.HTML, "Adult") {
+		t.Fatalf("unexpected filtered output: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `scope="row">1</th>`) {
+		t.Fatalf("expected visible rows numbered from 1, got %s", result.HTML)
+	}
+	if result.Metadata.RowCount != 1 || result.Metadata.FilteredCount != 2 {
+		t.Fatalf("unexpected metadata: %+v", result.Metadata)
+	}
+}
+
+func TestLimitOffset(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	data := []sampleRow{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+	result, err := RenderTableHTML(data, schema, Options{Offset: 2, Limit: 2})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `scope="row">3</th><td class="extable-cell cell-nowrap align-left extable-editable" data-col-key="name">c</td>`) {
+		t.Fatalf("expected row c numbered 3, got %s", result.HTML)
+	}
+	if strings.Contains(result.HTML, ">b<") || strings.Contains(result.HTML, ">e<") {
+		t.Fatalf("expected rows outside the window to be skipped, got %s", result.HTML)
+	}
+	if result.Metadata.RowCount != 2 || result.Metadata.TotalRows != 5 {
+		t.Fatalf("unexpected metadata: %+v", result.Metadata)
+	}
+}
+
+func TestVirtualFormulaColumn(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "decade", Type: ColumnTypeInt, Formula: func(row sampleRow) any { return row.Age / 10 * 10 }},
+		{Key: "name", Header: "Shout", Type: ColumnTypeString, Virtual: true, Formula: func(row sampleRow) any { return strings.ToUpper(row.Name) }},
+	}}
+	opts := Options{Sort: []SortSpec{{Key: "decade", Descending: true}}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Alice", Age: 34}, {Name: "Bob", Age: 51}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if len(result.Metadata.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %+v", result.Metadata.Warnings)
+	}
+	if !strings.Contains(result.HTML, ">50</td>") || !strings.Contains(result.HTML, ">BOB</td>") {
+		t.Fatalf("expected formula values, got %s", result.HTML)
+	}
+	if strings.Index(result.HTML, "BOB") > strings.Index(result.HTML, "ALICE") {
+		t.Fatalf("expected sort by formula value, got %s", result.HTML)
+	}
+}
+
+type partRow struct {
+	Name  string    `json:"name"`
+	Parts []partRow `json:"-"`
+}
+
+func TestTreeRows(t *testing.T) {
+	schema := Schema[partRow]{
+		Columns:  []Column[partRow]{{Key: "name", Type: ColumnTypeString}},
+		Children: func(row partRow) []partRow { return row.Parts },
+	}
+	data := []partRow{{Name: "bike", Parts: []partRow{{Name: "wheel", Parts: []partRow{{Name: "spoke"}}}, {Name: "frame"}}}}
+	result, err := RenderTableHTML(data, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	order := regexp.MustCompile(`data-depth="(\d)"`).FindAllStringSubmatch(result.HTML, -1)
+	depths := make([]string, len(order))
+	for i, m := range order {
+		depths[i] = m[1]
+	}
+	if strings.Join(depths, ",") != "0,1,2,1" {
+		t.Fatalf("unexpected depths %v in %s", depths, result.HTML)
+	}
+	if strings.Count(result.HTML, "extable-tree-toggle") != 2 || strings.Count(result.HTML, "extable-tree-spacer") != 2 {
+		t.Fatalf("expected toggles on parents and spacers on leaves, got %s", result.HTML)
+	}
+	if result.Metadata.RowCount != 4 {
+		t.Fatalf("expected flattened row count, got %d", result.Metadata.RowCount)
+	}
+}
+
+func TestDetailRows(t *testing.T) {
+	schema := Schema[sampleRow]{
+		Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}, {Key: "age", Type: ColumnTypeInt}},
+		DetailHTML: func(row sampleRow) SafeHTML {
+			if row.Age == 0 {
+				return ""
+			}
+			return SafeHTML("<p>" + strconv.Itoa(row.Age) + " years</p>")
+		},
+	}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Alice", Age: 30}, {Name: "Bob"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := `</tr><tr class="extable-detail" hidden=""><td class="extable-detail-cell" colspan="3"><p>30 years</p></td></tr>`
+	if !strings.Contains(result.HTML, want) {
+		t.Fatalf("expected detail row, got %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "extable-detail\"") != 1 {
+		t.Fatalf("expected empty detail to be skipped, got %s", result.HTML)
+	}
+}
+
+func TestRowHash(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}, {Key: "age", Type: ColumnTypeInt}}}
+	opts := Options{EmitRowHash: true}
+	first, err := RenderTableHTML([]sampleRow{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 40}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	second, err := RenderTableHTML([]sampleRow{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 41}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	a, b := first.Metadata.RowHashes, second.Metadata.RowHashes
+	if len(a) != 2 || len(b) != 2 {
+		t.Fatalf("expected two hashes, got %v and %v", a, b)
+	}
+	if a[0] != b[0] || a[1] == b[1] {
+		t.Fatalf("expected only the changed row hash to differ: %v vs %v", a, b)
+	}
+	if !strings.Contains(first.HTML, `<tr data-row-hash="`+a[0]+`">`) {
+		t.Fatalf("expected data-row-hash attribute, got %s", first.HTML)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	render := func(name string, opts Options) Result {
+		result, err := RenderTableHTML([]sampleRow{{Name: name}}, schema, opts)
+		if err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		return result
+	}
+	a := render("Alice", Options{})
+	if len(a.Metadata.Checksum) != 64 || a.Metadata.Checksum != render("Alice", Options{}).Metadata.Checksum {
+		t.Fatalf("expected stable sha-256 checksum, got %q", a.Metadata.Checksum)
+	}
+	if a.Metadata.Checksum == render("Bob", Options{}).Metadata.Checksum {
+		t.Fatalf("expected checksum to change with content")
+	}
+	hashed := Options{EmitRowHash: true, ChecksumIgnoreAttrs: []string{"data-row-hash"}}
+	if render("Alice", hashed).Metadata.Checksum != a.Metadata.Checksum {
+		t.Fatalf("expected ignored attributes to be excluded from the checksum")
+	}
+}
+
+func TestCSRFToken(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeButton}}}
+	opts := Options{CSRF: &CSRF{Token: `t"ok`}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Approve"}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<table data-csrf-field="csrf_token" data-csrf-token="t&quot;ok">`) {
+		t.Fatalf("expected csrf data attributes on table, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `</button><input type="hidden" name="csrf_token" value="t&quot;ok">`) {
+		t.Fatalf("expected hidden csrf input next to button, got %s", result.HTML)
+	}
+}
+
+func TestCellCoordinates(t *testing.T) {
+	schema := Schema[sampleRow]{
+		Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}},
+		RowID:   func(row sampleRow) string { return "p-" + row.Name },
+	}
+	result, err := RenderTableHTML([]sampleRow{{Name: "a"}, {Name: "b"}}, schema, Options{EmitCellCoordinates: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `data-col-key="name" data-row-id="p-b" data-row-index="1">b</td>`) {
+		t.Fatalf("expected cell coordinates, got %s", result.HTML)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	var stats []RenderStats
+	opts := Options{Metrics: MetricsFunc(func(s RenderStats) { stats = append(stats, s) })}
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "a"}, {Name: "b"}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Rows != 2 || stats[0].Bytes != len(result.HTML) || stats[0].Warnings != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestLoggerTracesFallbacks(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	type looseRow struct {
+		Age string `json:"age"`
+	}
+	schema := Schema[looseRow]{Columns: []Column[looseRow]{
+		{Key: "age", Type: ColumnTypeInt},
+		{Key: "missing", Type: ColumnTypeString},
+	}}
+	if _, err := RenderTableHTML([]looseRow{{Age: "n/a"}}, schema, Options{Logger: logger}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"schema resolved", "column key has no matching field", "col=missing", "using text fallback", "valueType=string"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in log output:\n%s", want, out)
+		}
+	}
+}
+
+func TestRootVersionAndSchemaHash(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	result, err := RenderTableHTML([]sampleRow{}, schema, Options{WrapWithRoot: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	hash := SchemaHash(schema)
+	if len(hash) != 16 || !strings.Contains(result.HTML, `data-extable-ssr-version="`+Version+`" data-schema-hash="`+hash+`"`) {
+		t.Fatalf("expected version and schema hash on root, got %s", result.HTML)
+	}
+	changed := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString, Readonly: true}}}
+	if SchemaHash(changed) == hash {
+		t.Fatalf("expected schema hash to change with the client-visible schema")
+	}
+}
+
+func TestResponsiveStack(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Header: "Full name", Type: ColumnTypeString}}}
+	opts := Options{Responsive: &Responsive{Mode: ResponsiveStack, Breakpoint: 640}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Alice"}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<table class="extable-responsive-stack" data-breakpoint="640" data-responsive="stack">`) {
+		t.Fatalf("expected responsive table attributes, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `data-col-key="name" data-label="Full name">Alice</td>`) {
+		t.Fatalf("expected data-label on cells, got %s", result.HTML)
+	}
+}
+
+func TestColumnPriority(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt, Priority: 3},
+	}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Alice", Age: 30}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<th data-col-key="age" scope="col" data-priority="3" class="extable-priority-3">`) {
+		t.Fatalf("expected priority on header, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `extable-priority-3 extable-editable" data-col-key="age" data-priority="3">30</td>`) {
+		t.Fatalf("expected priority on cell, got %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "data-priority") != 2 {
+		t.Fatalf("expected unprioritized column to stay unmarked, got %s", result.HTML)
+	}
+}
+
+func TestStickyHeaders(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	opts := Options{StickyHeader: true, StickyRowHeader: true}
+	result, err := RenderTableHTML([]sampleRow{{Name: "Alice"}}, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{
+		`<th class="extable-row-header extable-corner extable-sticky-header extable-sticky-row-header" data-col-key="" style="inset-inline-start: 0; position: sticky; top: 0; z-index: 3;">`,
+		`<th data-col-key="name" scope="col" class="extable-sticky-header" style="position: sticky; top: 0; z-index: 2;">`,
+		`<th class="extable-row-header extable-sticky-row-header" scope="row" style="inset-inline-start: 0; position: sticky; z-index: 1;">`,
+	} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s in %s", want, result.HTML)
+		}
+	}
+}
+
+func TestStripeBands(t *testing.T) {
+	schema := Schema[sampleRow]{
+		Columns:   []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}},
+		RowFilter: func(row sampleRow) bool { return row.Age == 0 },
+	}
+	data := []sampleRow{{Name: "a"}, {Name: "skip", Age: 1}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+	opts := Options{Stripe: &Stripe{Every: 2, Class: "band"}}
+	result, err := RenderTableHTML(data, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	rows := regexp.MustCompile(`<tr( class="band")?><th[^>]*>\d+</th><td[^>]*>(\w+)</td>`).FindAllStringSubmatch(result.HTML, -1)
+	banded := make([]string, 0)
+	for _, m := range rows {
+		if m[1] != "" {
+			banded = append(banded, m[2])
+		}
+	}
+	if len(rows) != 5 || strings.Join(banded, ",") != "c,d" {
+		t.Fatalf("expected rows c and d banded, got %v in %s", banded, result.HTML)
+	}
+}
+
+func TestHeaderIconAndSortIndicator(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString, HeaderIcon: SafeHTML(`<i class="icon-user"></i>`)},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "a", Age: 1}}, schema, Options{Sort: []SortSpec{{Key: "age", Descending: true}}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<span class="extable-col-header-icon"><i class="icon-user"></i></span><span class="extable-col-header-text">name</span>`) {
+		t.Fatalf("expected header icon, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<th data-col-key="age" scope="col" data-extable-sort-dir="desc" aria-sort="descending">`) {
+		t.Fatalf("expected sort attributes on header, got %s", result.HTML)
+	}
+	if strings.Count(result.HTML, "extable-sort-indicator") != 1 || !strings.Contains(result.HTML, `d="M12 18l-6-8h12l-6 8z"`) {
+		t.Fatalf("expected one descending indicator, got %s", result.HTML)
+	}
+}
+
+func TestColumnDescription(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "age", Type: ColumnTypeInt, Header: "Age", Description: "Age in years"},
+	}}
+	result, err := RenderTableHTML([]sampleRow{{Age: 3}}, schema, Options{EmitState: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<th data-col-key="age" scope="col" aria-describedby="extable-desc-age">`) {
+		t.Fatalf("expected aria-describedby on header, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<abbr title="Age in years">Age</abbr>`) {
+		t.Fatalf("expected abbr header, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<span id="extable-desc-age" class="extable-col-description" hidden="">Age in years</span>`) {
+		t.Fatalf("expected description element, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `"description":"Age in years"`) {
+		t.Fatalf("expected description in state, got %s", result.HTML)
+	}
+}
+
+func TestKeyboardNav(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	rows := []sampleRow{{Name: "a", Age: 1}, {Name: "b", Age: 2}}
+	result, err := RenderTableHTML(rows, schema, Options{KeyboardNav: true, Offset: 1})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Count(result.HTML, `tabindex="0"`) != 1 || !strings.Contains(result.HTML, `tabindex="0" data-nav="0,0"`) {
+		t.Fatalf("expected a single tab stop on the first rendered cell, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `tabindex="-1" data-nav="0,1"`) {
+		t.Fatalf("expected other cells to be focusable by script, got %s", result.HTML)
+	}
+}
+
+func TestColumnCustomAttributes(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{
+		Key:         "name",
+		Type:        ColumnTypeString,
+		HeaderAttrs: map[string]string{"data-track": "header", "x-data": "{open: false}"},
+		CellAttrs:   map[string]string{"x-on:click": `open = "yes"`, "class": "ignored", "bad name": "x"},
+	}}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "a"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<th data-col-key="name" scope="col" data-track="header" x-data="{open: false}">`) {
+		t.Fatalf("expected header attributes, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `data-col-key="name" x-on:click="open = &quot;yes&quot;">a</td>`) {
+		t.Fatalf("expected escaped cell attribute, got %s", result.HTML)
+	}
+	if strings.Contains(result.HTML, "ignored") || strings.Contains(result.HTML, "bad name") {
+		t.Fatalf("expected conflicting and invalid attributes to be dropped, got %s", result.HTML)
+	}
+	if len(result.Metadata.Warnings) != 2 {
+		t.Fatalf("expected two dropped-attribute warnings, got %+v", result.Metadata.Warnings)
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	schema := Schema[sampleRow]{
+		Columns: []Column[sampleRow]{
+			{Key: "name", Type: ColumnTypeString, Header: "N"},
+			{Key: "age", Type: ColumnTypeInt, Header: "Age in years"},
+		},
+		RowFilter: func(row sampleRow) bool { return row.Age > 1 },
+	}
+	rows := []sampleRow{{Name: "Alice", Age: 30}, {Name: "日本語テキスト", Age: 123456}, {Name: "this row is filtered out", Age: 1}}
+	result, err := RenderTableHTML(rows, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	widths := result.Metadata.ColumnWidths
+	if len(widths) != 2 || widths[0] != 7 || widths[1] != 12 {
+		t.Fatalf("unexpected widths %v", widths)
+	}
+}
+
+func TestLineClampAndRowHeight(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString, WrapText: true, MaxLines: 2}}}
+	result, err := RenderTableHTML([]sampleRow{{Name: "long text"}}, schema, Options{RowHeight: 48})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<tr data-row-height="48" style="height: 48px;">`) {
+		t.Fatalf("expected row height, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `class="extable-cell cell-wrap align-left extable-line-clamp extable-editable"`) {
+		t.Fatalf("expected clamp class, got %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<div class="extable-clamp" style="-webkit-box-orient: vertical; -webkit-line-clamp: 2; display: -webkit-box; overflow: hidden;">long text</div>`) {
+		t.Fatalf("expected clamp wrapper, got %s", result.HTML)
+	}
+	if _, err := RenderTableHTML([]sampleRow{}, schema, Options{RowHeight: -1}); err == nil {
+		t.Fatalf("expected negative RowHeight to be rejected")
+	}
+}
+
+func TestTreeCycles(t *testing.T) {
+	type node struct {
+		Name     string  `json:"name"`
+		Children []*node `json:"-"`
+	}
+	a := &node{Name: "a"}
+	b := &node{Name: "b", Children: []*node{a}}
+	a.Children = []*node{b}
+	pointers := Schema[*node]{
+		Columns:  []Column[*node]{{Key: "name", Type: ColumnTypeString}},
+		Children: func(n *node) []*node { return n.Children },
+	}
+	if _, err := RenderTableHTML([]*node{a}, pointers, Options{}); !errors.Is(err, ErrTreeCycle) {
+		t.Fatalf("expected a pointer cycle to fail, got %v", err)
+	}
+
+	byID := Schema[partRow]{
+		Columns:  []Column[partRow]{{Key: "name", Type: ColumnTypeString}},
+		Children: func(row partRow) []partRow { return []partRow{{Name: "loop"}} },
+		RowID:    func(row partRow) string { return row.Name },
+	}
+	if _, err := RenderTableHTML([]partRow{{Name: "root"}}, byID, Options{}); !errors.Is(err, ErrTreeCycle) || !strings.Contains(err.Error(), "loop") {
+		t.Fatalf("expected a RowID cycle to fail, got %v", err)
+	}
+
+	byID.RowID = nil
+	if _, err := RenderTableHTML([]partRow{{Name: "root"}}, byID, Options{}); !errors.Is(err, ErrTreeCycle) {
+		t.Fatalf("expected unbounded nesting to fail, got %v", err)
+	}
+}
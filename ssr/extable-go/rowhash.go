@@ -0,0 +1,24 @@
+package extable
+
+import (
+	"hash/fnv"
+	"strconv"
+)
+
+// rowHash is an FNV-1a digest of the row's formatted cell values, so it
+// changes only when the rendered text of some cell changes.
+func (r *tableRenderer[T]) rowHash(row T) string {
+	h := fnv.New64a()
+	for _, col := range r.columns {
+		value, _ := columnValue(r.getter, row, col)
+		h.Write([]byte(col.Key))
+		h.Write([]byte{0x1f})
+		h.Write([]byte(formatValue(value, col, r.opts)))
+		h.Write([]byte{0x1e})
+	}
+	text := strconv.FormatUint(h.Sum64(), 16)
+	for len(text) < 16 {
+		text = "0" + text
+	}
+	return text
+}
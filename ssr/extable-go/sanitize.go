@@ -0,0 +1,109 @@
+package extable
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+)
+
+// Sanitizer cleans untrusted HTML before it is embedded in a rich text cell.
+// *bluemonday.Policy satisfies this interface.
+type Sanitizer interface {
+	Sanitize(html string) string
+}
+
+// BasicSanitizer keeps a small set of formatting elements and drops every
+// attribute except href on links, which must pass URLPolicy. The markup is
+// cleaned by a bluemonday policy; URLPolicy then filters and rewrites hrefs,
+// and unclosed or stray tags are balanced so they cannot affect the table.
+type BasicSanitizer struct {
+	URLPolicy *URLPolicy
+}
+
+var basicSanitizerTags = []string{
+	"a", "b", "blockquote", "br", "code", "em", "i", "li", "ol",
+	"p", "pre", "s", "strong", "sub", "sup", "u", "ul",
+}
+
+var (
+	basicPolicyOnce sync.Once
+	basicPolicy     *bluemonday.Policy
+)
+
+// basicSanitizerPolicy accepts any parseable href; URLPolicy decides afterwards,
+// since bluemonday can neither check hosts on relative URLs nor rewrite them.
+func basicSanitizerPolicy() *bluemonday.Policy {
+	basicPolicyOnce.Do(func() {
+		basicPolicy = bluemonday.NewPolicy()
+		basicPolicy.AllowElements(basicSanitizerTags...)
+		basicPolicy.AllowAttrs("href").OnElements("a")
+		basicPolicy.RequireParseableURLs(true)
+		basicPolicy.AllowRelativeURLs(true)
+		basicPolicy.AllowURLSchemesMatching(regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*$`))
+	})
+	return basicPolicy
+}
+
+func (o *Options) sanitizer() Sanitizer {
+	if o.Sanitizer != nil {
+		return o.Sanitizer
+	}
+	return BasicSanitizer{URLPolicy: o.urlPolicy()}
+}
+
+func (s BasicSanitizer) Sanitize(input string) string {
+	policy := s.URLPolicy
+	if policy == nil {
+		policy = DefaultURLPolicy
+	}
+	cleaned := basicSanitizerPolicy().Sanitize(input)
+	var sb strings.Builder
+	open := make([]string, 0)
+	tokenizer := html.NewTokenizer(strings.NewReader(cleaned))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			for i := len(open) - 1; i >= 0; i-- {
+				sb.WriteString("</" + open[i] + ">")
+			}
+			return sb.String()
+		case html.EndTagToken:
+			name := tokenizer.Token().Data
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != name {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					sb.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Type == html.StartTagToken && token.Data != "br" {
+				open = append(open, token.Data)
+			}
+			if token.Data != "a" {
+				sb.WriteString(token.String())
+				continue
+			}
+			attrs := make([]html.Attribute, 0, 2)
+			for _, attr := range token.Attr {
+				if attr.Key != "href" {
+					continue
+				}
+				if href, ok := policy.sanitize(attr.Val); ok {
+					attrs = append(attrs, html.Attribute{Key: "href", Val: href}, html.Attribute{Key: "rel", Val: "nofollow noopener"})
+				}
+			}
+			token.Attr = attrs
+			sb.WriteString(token.String())
+		default:
+			sb.Write(tokenizer.Raw())
+		}
+	}
+}
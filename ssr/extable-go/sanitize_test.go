@@ -0,0 +1,144 @@
+package extable
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestBasicSanitizer(t *testing.T) {
+	cases := map[string]string{
+		`<b>bold</b> &amp; <i>it</i>`:                           `<b>bold</b> &amp; <i>it</i>`,
+		`<p onclick="x()">hi<script>alert(1)</script></p>`:      `<p>hi</p>`,
+		`<a href="javascript:alert(1)">x</a>`:                   `<a>x</a>`,
+		`<a href="https://example.com/?a=1&amp;b=2" id=x>x</a>`: `<a href="https://example.com/?a=1&amp;b=2" rel="nofollow noopener">x</a>`,
+		`<ul><li>one<li>two</ul>`:                               `<ul><li>one<li>two</li></li></ul>`,
+		`<strong>open`:                                          `<strong>open</strong>`,
+		`a < b <!-- c --> "q"`:                                  `a &lt; b  &#34;q&#34;`,
+		`<img src=x onerror=alert(1)>`:                          ``,
+		`</em>stray`:                                            `stray`,
+	}
+	sanitizer := BasicSanitizer{}
+	for input, want := range cases {
+		if got := sanitizer.Sanitize(input); got != want {
+			t.Errorf("Sanitize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// Mutation XSS payloads rely on the browser re-parsing markup differently
+// from the sanitizer; none may leave an active element or attribute behind.
+var mutationXSSCases = []string{
+	`<noscript><p title="</noscript><img src=x onerror=alert(1)>">`,
+	`<svg><p><style><img src=x onerror=alert(1)></style></p></svg>`,
+	`<math><mtext><table><mglyph><style><img src=x onerror=alert(1)>`,
+	`<form><math><mtext></form><form><mglyph><style></math><img src onerror=alert(1)>`,
+	`<a href="jav&#x09;ascript:alert(1)">x</a>`,
+	`<a href="&#106;avascript:alert(1)">x</a>`,
+	`<a href=" javascript:alert(1)">x</a>`,
+	`<a href="//evil.example/">x</a><a href="/\evil.example/">y</a>`,
+	`<a href="javascript:alert(1)"/>x`,
+	`<p/onclick=alert(1)>x`,
+	`<<script>script>alert(1)<</script>/script>`,
+	`<!--><img src=x onerror=alert(1)>-->`,
+	`<textarea></textarea><img src=x onerror=alert(1)>`,
+}
+
+func TestBasicSanitizerMutationXSS(t *testing.T) {
+	sanitizer := BasicSanitizer{URLPolicy: &URLPolicy{AllowedSchemes: []string{"https"}, AllowedHosts: []string{"example.com"}}}
+	for _, input := range mutationXSSCases {
+		if problem := unsafeSanitizerOutput(sanitizer.Sanitize(input)); problem != "" {
+			t.Errorf("Sanitize(%q): %s", input, problem)
+		}
+	}
+}
+
+func FuzzBasicSanitizer(f *testing.F) {
+	for _, input := range mutationXSSCases {
+		f.Add(input)
+	}
+	f.Add(`<b>bold</b><a href="https://example.com/">x</a>`)
+	sanitizer := BasicSanitizer{URLPolicy: &URLPolicy{AllowedSchemes: []string{"https"}, AllowedHosts: []string{"example.com"}}}
+	f.Fuzz(func(t *testing.T, input string) {
+		out := sanitizer.Sanitize(input)
+		if problem := unsafeSanitizerOutput(out); problem != "" {
+			t.Fatalf("Sanitize(%q) = %q: %s", input, out, problem)
+		}
+	})
+}
+
+// unsafeSanitizerOutput re-parses out the way a browser would and reports the
+// first element, attribute or href the basic policy should not allow.
+func unsafeSanitizerOutput(out string) string {
+	context := &html.Node{Type: html.ElementNode, Data: "td", DataAtom: atom.Td}
+	nodes, err := html.ParseFragment(strings.NewReader(out), context)
+	if err != nil {
+		return err.Error()
+	}
+	var check func(*html.Node) string
+	check = func(n *html.Node) string {
+		if n.Type == html.ElementNode {
+			if !slices.Contains(basicSanitizerTags, n.Data) {
+				return "element " + n.Data + " survived"
+			}
+			for _, attr := range n.Attr {
+				switch {
+				case n.Data == "a" && attr.Key == "href":
+					u, err := url.Parse(attr.Val)
+					allowed := err == nil && u.Opaque == "" && (u.Scheme == "" || u.Scheme == "https") && (u.Host == "" || u.Host == "example.com")
+					if !allowed || strings.HasPrefix(attr.Val, "//") || strings.Contains(attr.Val, "\\") {
+						return "href " + attr.Val + " survived"
+					}
+				case n.Data == "a" && attr.Key == "rel":
+				default:
+					return "attribute " + attr.Key + " survived"
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if problem := check(c); problem != "" {
+				return problem
+			}
+		}
+		return ""
+	}
+	for _, n := range nodes {
+		if problem := check(n); problem != "" {
+			return problem
+		}
+	}
+	return ""
+}
+
+type articleRow struct {
+	Body string `json:"body"`
+}
+
+type upperSanitizer struct{}
+
+func (upperSanitizer) Sanitize(html string) string {
+	return strings.ToUpper(html)
+}
+
+func TestRenderRichText(t *testing.T) {
+	schema := Schema[articleRow]{Columns: []Column[articleRow]{{Key: "body", Type: ColumnTypeRichText}}}
+	data := []articleRow{{Body: `<em>hello</em><script>x</script>`}}
+	result, err := RenderTableHTML(data, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `extable-richtext`) || !strings.Contains(result.HTML, `"><em>hello</em></td>`) {
+		t.Fatalf("expected sanitized rich text: %s", result.HTML)
+	}
+	custom, err := RenderTableHTML(data, schema, Options{Sanitizer: upperSanitizer{}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(custom.HTML, "<EM>HELLO</EM>") {
+		t.Fatalf("expected custom sanitizer to be used")
+	}
+}
@@ -0,0 +1,69 @@
+package extable
+
+// Merge returns a schema with the columns of s followed by those of other. A
+// column in other whose key already exists in s replaces it in place, and the
+// row-level functions of other take precedence when set.
+func (s Schema[T]) Merge(other Schema[T]) Schema[T] {
+	merged := s
+	merged.Columns = append([]Column[T](nil), s.Columns...)
+	index := make(map[string]int, len(merged.Columns))
+	for i, col := range merged.Columns {
+		index[col.Key] = i
+	}
+	for _, col := range other.Columns {
+		if i, ok := index[col.Key]; ok {
+			merged.Columns[i] = col
+			continue
+		}
+		index[col.Key] = len(merged.Columns)
+		merged.Columns = append(merged.Columns, col)
+	}
+	if other.Children != nil {
+		merged.Children = other.Children
+	}
+	if other.DetailHTML != nil {
+		merged.DetailHTML = other.DetailHTML
+	}
+	if other.RowID != nil {
+		merged.RowID = other.RowID
+	}
+	if other.RowFilter != nil {
+		merged.RowFilter = other.RowFilter
+	}
+	if other.ColumnVisible != nil {
+		merged.ColumnVisible = other.ColumnVisible
+	}
+	return merged
+}
+
+// Select returns a schema with only the named columns, in the order given.
+// Keys that are not in s are ignored.
+func (s Schema[T]) Select(keys ...string) Schema[T] {
+	selected := s
+	selected.Columns = make([]Column[T], 0, len(keys))
+	for _, key := range keys {
+		for _, col := range s.Columns {
+			if col.Key == key {
+				selected.Columns = append(selected.Columns, col)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// Without returns a schema with the named columns removed.
+func (s Schema[T]) Without(keys ...string) Schema[T] {
+	drop := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		drop[key] = true
+	}
+	remaining := s
+	remaining.Columns = make([]Column[T], 0, len(s.Columns))
+	for _, col := range s.Columns {
+		if !drop[col.Key] {
+			remaining.Columns = append(remaining.Columns, col)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,42 @@
+package extable
+
+import "testing"
+
+func columnKeys[T any](schema Schema[T]) []string {
+	keys := make([]string, len(schema.Columns))
+	for i, col := range schema.Columns {
+		keys[i] = col.Key
+	}
+	return keys
+}
+
+func TestSchemaComposition(t *testing.T) {
+	base := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	admin := base.Merge(Schema[sampleRow]{
+		Columns: []Column[sampleRow]{
+			{Key: "age", Type: ColumnTypeInt, Readonly: true},
+			{Key: "double", Type: ColumnTypeInt, Virtual: true, Formula: func(row sampleRow) any { return row.Age * 2 }},
+		},
+		RowID: func(row sampleRow) string { return row.Name },
+	})
+	if got := columnKeys(admin); len(got) != 3 || got[0] != "name" || got[1] != "age" || got[2] != "double" {
+		t.Fatalf("unexpected merged keys %v", got)
+	}
+	if !admin.Columns[1].Readonly || admin.RowID == nil {
+		t.Fatalf("expected merged column and RowID to override")
+	}
+	if base.Columns[1].Readonly || len(base.Columns) != 2 {
+		t.Fatalf("merge must not modify the receiver")
+	}
+
+	summary := admin.Select("double", "name", "missing")
+	if got := columnKeys(summary); len(got) != 2 || got[0] != "double" || got[1] != "name" {
+		t.Fatalf("unexpected selected keys %v", got)
+	}
+	if got := columnKeys(admin.Without("age")); len(got) != 2 || got[0] != "name" || got[1] != "double" {
+		t.Fatalf("unexpected remaining keys %v", got)
+	}
+}
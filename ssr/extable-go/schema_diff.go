@@ -0,0 +1,97 @@
+package extable
+
+import (
+	"reflect"
+	"strings"
+)
+
+type SchemaDiff struct {
+	Added   []string
+	Removed []string
+	Changed []ColumnChange
+}
+
+type ColumnChange struct {
+	Key    string
+	Fields []string
+}
+
+func (d SchemaDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffSchemas reports how b differs from a, keyed by column key.
+// Fields that are not serialised, such as Formula, Less and Table, are not
+// compared, though setting Formula still shows as a readonly change.
+func DiffSchemas[T any](a, b Schema[T]) SchemaDiff {
+	diff := SchemaDiff{Added: []string{}, Removed: []string{}, Changed: []ColumnChange{}}
+	before := make(map[string]Column[T], len(a.Columns))
+	for _, col := range a.Columns {
+		before[col.Key] = col
+	}
+	after := make(map[string]bool, len(b.Columns))
+	for _, col := range b.Columns {
+		after[col.Key] = true
+		old, ok := before[col.Key]
+		if !ok {
+			diff.Added = append(diff.Added, col.Key)
+			continue
+		}
+		if fields := changedColumnFields(old, col); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, ColumnChange{Key: col.Key, Fields: fields})
+		}
+	}
+	for _, col := range a.Columns {
+		if !after[col.Key] {
+			diff.Removed = append(diff.Removed, col.Key)
+		}
+	}
+	return diff
+}
+
+// changedColumnFields compares every serialisable field of the columns, named
+// by its JSON key. Readonly also covers the change between stored and derived.
+func changedColumnFields[T any](a, b Column[T]) []string {
+	fields := make([]string, 0)
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for i := 0; i < va.NumField(); i++ {
+		name, compared := diffFieldName(va.Type().Field(i))
+		if !compared {
+			continue
+		}
+		var changed bool
+		switch name {
+		case "readonly":
+			changed = a.Readonly != b.Readonly || a.derived() != b.derived()
+		case "actions":
+			changed = !reflect.DeepEqual(actionsForDiff(a.Actions), actionsForDiff(b.Actions))
+		default:
+			changed = !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface())
+		}
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// diffFieldName returns the JSON key of a Column field, or false for the key
+// itself and for fields that are not serialised.
+func diffFieldName(field reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if field.Name == "Key" || name == "-" || field.Type.Kind() == reflect.Func {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+func actionsForDiff[T any](actions []Action[T]) []string {
+	keys := make([]string, 0, len(actions))
+	for _, action := range actions {
+		keys = append(keys, action.ID+"\x00"+action.Label)
+	}
+	return keys
+}
@@ -0,0 +1,80 @@
+package extable
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	scale := 2
+	a := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+		{Key: "legacy", Type: ColumnTypeString},
+	}}
+	b := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeNumber, Readonly: true, Format: &Format{NumberScale: &scale}},
+		{Key: "email", Type: ColumnTypeString},
+	}}
+	diff := DiffSchemas(a, b)
+	if !reflect.DeepEqual(diff.Added, []string{"email"}) || !reflect.DeepEqual(diff.Removed, []string{"legacy"}) {
+		t.Fatalf("unexpected added/removed: %+v", diff)
+	}
+	expected := []ColumnChange{{Key: "age", Fields: []string{"type", "readonly", "format"}}}
+	if !reflect.DeepEqual(diff.Changed, expected) {
+		t.Fatalf("unexpected changes: %+v", diff.Changed)
+	}
+	if !DiffSchemas(a, a).Empty() {
+		t.Fatalf("expected identical schemas to produce an empty diff")
+	}
+}
+
+// TestDiffSchemasCoversColumnFields fails when a Column field is added without
+// DiffSchemas noticing changes to it; list deliberately skipped fields here.
+func TestDiffSchemasCoversColumnFields(t *testing.T) {
+	notCompared := map[string]bool{"Key": true, "Formula": true, "Less": true, "HeaderIcon": true, "Table": true}
+	columnType := reflect.TypeOf(Column[sampleRow]{})
+	for i := 0; i < columnType.NumField(); i++ {
+		field := columnType.Field(i)
+		if notCompared[field.Name] {
+			continue
+		}
+		changed := Column[sampleRow]{Key: "c"}
+		value := reflect.ValueOf(&changed).Elem().Field(i)
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString("x")
+		case reflect.Bool:
+			value.SetBool(true)
+		case reflect.Int:
+			value.SetInt(1)
+		case reflect.Ptr:
+			value.Set(reflect.New(field.Type.Elem()))
+		case reflect.Slice:
+			value.Set(reflect.MakeSlice(field.Type, 1, 1))
+		case reflect.Map:
+			value.Set(reflect.MakeMap(field.Type))
+			value.SetMapIndex(reflect.New(field.Type.Key()).Elem(), reflect.New(field.Type.Elem()).Elem())
+		default:
+			t.Fatalf("field %s has kind %s; extend this test", field.Name, value.Kind())
+		}
+		diff := DiffSchemas(
+			Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "c"}}},
+			Schema[sampleRow]{Columns: []Column[sampleRow]{changed}},
+		)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if len(diff.Changed) != 1 || !slices.Contains(diff.Changed[0].Fields, name) {
+			t.Errorf("changing Column.%s is not reported as %q: %+v", field.Name, name, diff.Changed)
+		}
+	}
+	derived := DiffSchemas(
+		Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "c"}}},
+		Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "c", Expr: "1"}}},
+	)
+	if len(derived.Changed) != 1 || !reflect.DeepEqual(derived.Changed[0].Fields, []string{"readonly", "expr"}) {
+		t.Fatalf("expected Expr to make the column readonly: %+v", derived.Changed)
+	}
+}
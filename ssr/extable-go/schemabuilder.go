@@ -0,0 +1,140 @@
+package extable
+
+// SchemaBuilder assembles a Schema column by column. Type methods such as
+// String and Int start a column; modifiers such as Header and Readonly apply
+// to the most recently started one. Problems surface from Build.
+type SchemaBuilder[T any] struct {
+	schema Schema[T]
+	err    error
+}
+
+func NewSchema[T any]() *SchemaBuilder[T] {
+	return &SchemaBuilder[T]{}
+}
+
+// Column starts a column of any type, including registered custom types.
+func (b *SchemaBuilder[T]) Column(key string, colType ColumnType) *SchemaBuilder[T] {
+	b.schema.Columns = append(b.schema.Columns, Column[T]{Key: key, Type: colType})
+	return b
+}
+
+func (b *SchemaBuilder[T]) String(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeString)
+}
+
+func (b *SchemaBuilder[T]) Number(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeNumber)
+}
+
+func (b *SchemaBuilder[T]) Int(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeInt)
+}
+
+func (b *SchemaBuilder[T]) Uint(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeUint)
+}
+
+func (b *SchemaBuilder[T]) Boolean(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeBoolean)
+}
+
+func (b *SchemaBuilder[T]) Date(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeDate)
+}
+
+func (b *SchemaBuilder[T]) Time(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeTime)
+}
+
+func (b *SchemaBuilder[T]) DateTime(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeDateTime)
+}
+
+// Enum starts an enum column allowing values in the given order.
+func (b *SchemaBuilder[T]) Enum(key string, values ...string) *SchemaBuilder[T] {
+	b.Column(key, ColumnTypeEnum)
+	return b.Configure(func(col *Column[T]) { col.Enum = &EnumSpec{Values: values} })
+}
+
+func (b *SchemaBuilder[T]) Tags(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeTags)
+}
+
+func (b *SchemaBuilder[T]) Link(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeLink)
+}
+
+func (b *SchemaBuilder[T]) Button(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeButton)
+}
+
+func (b *SchemaBuilder[T]) Image(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeImage)
+}
+
+func (b *SchemaBuilder[T]) RichText(key string) *SchemaBuilder[T] {
+	return b.Column(key, ColumnTypeRichText)
+}
+
+func (b *SchemaBuilder[T]) Actions(key string, actions ...Action[T]) *SchemaBuilder[T] {
+	b.Column(key, ColumnTypeActions)
+	return b.Configure(func(col *Column[T]) { col.Actions = actions })
+}
+
+// Configure edits the current column directly, for fields without a dedicated modifier.
+func (b *SchemaBuilder[T]) Configure(fn func(col *Column[T])) *SchemaBuilder[T] {
+	if len(b.schema.Columns) == 0 {
+		if b.err == nil {
+			b.err = ErrBuilderNoColumn
+		}
+		return b
+	}
+	fn(&b.schema.Columns[len(b.schema.Columns)-1])
+	return b
+}
+
+func (b *SchemaBuilder[T]) Header(header string) *SchemaBuilder[T] {
+	return b.Configure(func(col *Column[T]) { col.Header = header })
+}
+
+func (b *SchemaBuilder[T]) Description(description string) *SchemaBuilder[T] {
+	return b.Configure(func(col *Column[T]) { col.Description = description })
+}
+
+func (b *SchemaBuilder[T]) Readonly() *SchemaBuilder[T] {
+	return b.Configure(func(col *Column[T]) { col.Readonly = true })
+}
+
+func (b *SchemaBuilder[T]) WrapText() *SchemaBuilder[T] {
+	return b.Configure(func(col *Column[T]) { col.WrapText = true })
+}
+
+func (b *SchemaBuilder[T]) Format(format Format) *SchemaBuilder[T] {
+	return b.Configure(func(col *Column[T]) { col.Format = &format })
+}
+
+// Formula computes the current column from the row; the column becomes virtual
+// so it needs no backing field.
+func (b *SchemaBuilder[T]) Formula(fn func(T) any) *SchemaBuilder[T] {
+	return b.Configure(func(col *Column[T]) {
+		col.Formula = fn
+		col.Virtual = true
+	})
+}
+
+func (b *SchemaBuilder[T]) Priority(priority int) *SchemaBuilder[T] {
+	return b.Configure(func(col *Column[T]) { col.Priority = priority })
+}
+
+// Build returns the schema after Schema.Validate accepts it.
+func (b *SchemaBuilder[T]) Build() (Schema[T], error) {
+	if b.err != nil {
+		return Schema[T]{}, b.err
+	}
+	schema := b.schema
+	schema.Columns = append([]Column[T](nil), b.schema.Columns...)
+	if err := schema.Validate(); err != nil {
+		return Schema[T]{}, err
+	}
+	return schema, nil
+}
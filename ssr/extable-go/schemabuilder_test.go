@@ -0,0 +1,45 @@
+package extable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSchemaBuilder(t *testing.T) {
+	schema, err := NewSchema[sampleRow]().
+		String("name").Header("Name").Readonly().
+		Int("age").Header("Age").Priority(2).
+		Number("double").Formula(func(row sampleRow) any { return row.Age * 2 }).
+		Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	if len(schema.Columns) != 3 || !schema.Columns[0].Readonly || schema.Columns[1].Header != "Age" || schema.Columns[1].Priority != 2 {
+		t.Fatalf("unexpected schema %+v", schema.Columns)
+	}
+	result, err := RenderTableHTML([]sampleRow{{Name: "a", Age: 4}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `data-col-key="double">8</td>`) {
+		t.Fatalf("expected formula column, got %s", result.HTML)
+	}
+}
+
+func TestSchemaBuilderErrors(t *testing.T) {
+	if _, err := NewSchema[sampleRow]().Header("x").String("name").Build(); !errors.Is(err, ErrBuilderNoColumn) {
+		t.Fatalf("expected modifier without column to fail")
+	}
+	_, err := NewSchema[sampleRow]().String("name").Int("name").Build()
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || !strings.Contains(err.Error(), "more than once") {
+		t.Fatalf("expected duplicate key error, got %v", err)
+	}
+	if _, err := NewSchema[sampleRow]().String("nickname").Build(); err == nil || !strings.Contains(err.Error(), "matches no field") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+	if _, err := NewSchema[sampleRow]().Column("name", "nope").Build(); err == nil || !strings.Contains(err.Error(), "unknown type") {
+		t.Fatalf("expected unknown type error, got %v", err)
+	}
+}
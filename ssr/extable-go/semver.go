@@ -0,0 +1,128 @@
+package extable
+
+import (
+	"strconv"
+	"strings"
+)
+
+type semver struct {
+	major, minor, patch string
+	prerelease          []string
+	build               string
+}
+
+// semverValue parses a SemVer 2.0.0 string, tolerating a leading "v".
+func semverValue(value any) (semver, bool) {
+	text, ok := value.(string)
+	if !ok {
+		if stringer, isStringer := value.(interface{ String() string }); isStringer {
+			text = stringer.String()
+		} else {
+			return semver{}, false
+		}
+	}
+	text = strings.TrimPrefix(strings.TrimSpace(text), "v")
+	var v semver
+	text, build, hasBuild := strings.Cut(text, "+")
+	v.build = build
+	core, pre, hasPre := strings.Cut(text, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return semver{}, false
+	}
+	for _, part := range parts {
+		if !isSemverNumber(part) {
+			return semver{}, false
+		}
+	}
+	v.major, v.minor, v.patch = parts[0], parts[1], parts[2]
+	if hasPre {
+		v.prerelease = strings.Split(pre, ".")
+		for _, id := range v.prerelease {
+			if !isSemverIdentifier(id) || (isDigits(id) && !isSemverNumber(id)) {
+				return semver{}, false
+			}
+		}
+	}
+	if hasBuild {
+		for _, id := range strings.Split(v.build, ".") {
+			if !isSemverIdentifier(id) {
+				return semver{}, false
+			}
+		}
+	}
+	return v, true
+}
+
+func (v semver) String() string {
+	text := v.major + "." + v.minor + "." + v.patch
+	if len(v.prerelease) > 0 {
+		text += "-" + strings.Join(v.prerelease, ".")
+	}
+	if v.build != "" {
+		text += "+" + v.build
+	}
+	return text
+}
+
+// sortKey encodes precedence so keys compare lexically: numbers carry a
+// two-digit length prefix, numeric identifiers sort before alphanumeric ones,
+// a space ends each identifier, and a release ("~") sorts after its
+// prereleases ("-"). Build metadata is ignored, as the spec requires.
+func (v semver) sortKey() string {
+	var sb strings.Builder
+	for _, n := range []string{v.major, v.minor, v.patch} {
+		sb.WriteString(semverNumberKey(n))
+		sb.WriteString(".")
+	}
+	if len(v.prerelease) == 0 {
+		sb.WriteString("~")
+		return sb.String()
+	}
+	sb.WriteString("-")
+	for _, id := range v.prerelease {
+		if isDigits(id) {
+			sb.WriteString("0" + semverNumberKey(id))
+		} else {
+			sb.WriteString("1" + id)
+		}
+		sb.WriteString(" ")
+	}
+	return sb.String()
+}
+
+func semverNumberKey(n string) string {
+	length := strconv.Itoa(len(n))
+	if len(length) < 2 {
+		length = "0" + length
+	}
+	return length + n
+}
+
+func isSemverNumber(s string) bool {
+	return isDigits(s) && (s == "0" || s[0] != '0')
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSemverIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !(c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
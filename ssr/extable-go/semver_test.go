@@ -0,0 +1,53 @@
+package extable
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSemverSortKey(t *testing.T) {
+	// Precedence order from the SemVer 2.0.0 specification, plus a wide number.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "v1.0.0", "1.2.0", "1.10.0", "10.0.0",
+	}
+	keys := make([]string, len(ordered))
+	for i, text := range ordered {
+		v, ok := semverValue(text)
+		if !ok {
+			t.Fatalf("%q did not parse", text)
+		}
+		keys[i] = v.sortKey()
+	}
+	if !sort.StringsAreSorted(keys) {
+		t.Fatalf("keys are not in precedence order: %q", keys)
+	}
+	for _, bad := range []string{"1.0", "01.0.0", "1.0.0-01", "1.0.0+", "1.0.0-a..b", "1.0.x"} {
+		if _, ok := semverValue(bad); ok {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestSemverColumn(t *testing.T) {
+	type release struct {
+		Version string `json:"version"`
+	}
+	schema := Schema[release]{Columns: []Column[release]{{Key: "version", Type: ColumnTypeSemver}}}
+	rows := []release{{Version: "v1.10.0"}, {Version: "1.9.0+build.5"}, {Version: "latest"}}
+	result, err := RenderTableHTML(rows, schema, Options{Sort: []SortSpec{{Key: "version"}}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	if strings.Index(html, ">1.9.0+build.5<") > strings.Index(html, ">1.10.0<") {
+		t.Fatalf("expected precedence ordering: %s", html)
+	}
+	if !strings.Contains(html, `data-value="011.0210.010.~">1.10.0</td>`) {
+		t.Fatalf("missing normalized version and sort key: %s", html)
+	}
+	if len(result.Metadata.Warnings) != 1 || result.Metadata.Warnings[0].Message != "invalid semantic version" {
+		t.Fatalf("expected a warning for the invalid version: %v", result.Metadata.Warnings)
+	}
+}
@@ -0,0 +1,73 @@
+package extable
+
+import (
+	"sort"
+	"strings"
+)
+
+// Snapshot holds the numeric cell values of a dataset by row id and column
+// key, as written by the cells' data-prev-value.
+type Snapshot map[string]map[string]string
+
+// TakeSnapshot records data's numeric values for Options.PreviousValues on
+// the next render. It needs Schema.RowID.
+func TakeSnapshot[T any](data []T, schema Schema[T]) (Snapshot, error) {
+	if schema.RowID == nil {
+		return nil, ErrMissingRowID
+	}
+	getter, err := newFieldGetter[T]()
+	if err != nil {
+		return nil, err
+	}
+	schema = schema.withDynamicColumns(data)
+	snapshot := make(Snapshot, len(data))
+	for _, row := range data {
+		values := make(map[string]string)
+		for _, col := range schema.Columns {
+			if !animatedColumn(col) {
+				continue
+			}
+			value, _ := columnValue(getter, row, col)
+			if text := exportText(value, col); text != "" {
+				values[col.Key] = text
+			}
+		}
+		snapshot[schema.RowID(row)] = values
+	}
+	return snapshot, nil
+}
+
+func animatedColumn[T any](col Column[T]) bool {
+	switch col.Type {
+	case ColumnTypeNumber, ColumnTypeInt, ColumnTypeUint:
+		return true
+	}
+	return false
+}
+
+func (r *tableRenderer[T]) prevValueAttrs(row T, col Column[T], value any) []string {
+	if r.opts.PreviousValues == nil || r.rowID == nil || !animatedColumn(col) {
+		return nil
+	}
+	prev, ok := r.opts.PreviousValues[r.rowID(row)][col.Key]
+	if !ok || prev == exportText(value, col) {
+		return nil
+	}
+	return []string{"data-prev-value", prev}
+}
+
+func snapshotKey(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, key := range keys {
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(values[key])
+		sb.WriteString("\x00")
+	}
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package extable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPreviousValues(t *testing.T) {
+	schema := Schema[exportRow]{
+		Columns: []Column[exportRow]{
+			{Key: "id", Type: ColumnTypeInt},
+			{Key: "name", Type: ColumnTypeString},
+		},
+		RowID: func(row exportRow) string { return row.Name },
+	}
+	before := []exportRow{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	snapshot, err := TakeSnapshot(before, schema)
+	if err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+	if snapshot["a"]["id"] != "1" || len(snapshot["a"]) != 1 {
+		t.Fatalf("unexpected snapshot: %v", snapshot)
+	}
+
+	after := []exportRow{{ID: 5, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	opts := Options{PreviousValues: snapshot}
+	result, err := RenderTableHTML(after, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Count(result.HTML, "data-prev-value") != 1 || !strings.Contains(result.HTML, `data-prev-value="1">5</td>`) {
+		t.Fatalf("expected one changed cell: %s", result.HTML)
+	}
+
+	cache := NewMemoryCache()
+	if _, err := RenderCached(cache, "t", "v", after, schema, opts); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	opts.PreviousValues = map[string]map[string]string{"a": {"id": "4"}}
+	cached, err := RenderCached(cache, "t", "v", after, schema, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(cached.HTML, `data-prev-value="4"`) {
+		t.Fatalf("cache must not serve stale previous values: %s", cached.HTML)
+	}
+
+	schema.RowID = nil
+	if _, err := TakeSnapshot(before, schema); !errors.Is(err, ErrMissingRowID) {
+		t.Fatalf("expected ErrMissingRowID, got %v", err)
+	}
+	if _, err := RenderTableHTML(after, schema, opts); !errors.Is(err, ErrMissingRowID) {
+		t.Fatalf("expected ErrMissingRowID, got %v", err)
+	}
+}
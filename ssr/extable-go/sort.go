@@ -0,0 +1,209 @@
+package extable
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+type SortSpec struct {
+	Key        string
+	Descending bool
+}
+
+// Collator compares strings for sorting. *collate.Collator from
+// golang.org/x/text/collate satisfies this interface.
+type Collator interface {
+	CompareString(a, b string) int
+}
+
+func sortRows[T any](data []T, columns []Column[T], getter *fieldGetter, opts *Options) ([]T, error) {
+	if len(opts.Sort) == 0 {
+		return data, nil
+	}
+	byKey := make(map[string]Column[T], len(columns))
+	for _, col := range columns {
+		byKey[col.Key] = col
+	}
+	specs := make([]Column[T], 0, len(opts.Sort))
+	for _, spec := range opts.Sort {
+		col, ok := byKey[spec.Key]
+		if !ok {
+			return nil, &SchemaError{ColKey: spec.Key, Message: "is a sort key but not a schema column"}
+		}
+		specs = append(specs, col)
+	}
+
+	sorted := make([]T, len(data))
+	copy(sorted, data)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for n, col := range specs {
+			cmp := 0
+			if col.Less != nil {
+				cmp = compareWithLess(sorted[i], sorted[j], col.Less)
+				if opts.Sort[n].Descending {
+					cmp = -cmp
+				}
+			} else {
+				a, _ := columnValue(getter, sorted[i], col)
+				b, _ := columnValue(getter, sorted[j], col)
+				var nilOrder bool
+				cmp, nilOrder = compareNil(a, b)
+				if !nilOrder {
+					cmp = compareColumnValues(col.Type, a, b, opts)
+					if opts.Sort[n].Descending {
+						cmp = -cmp
+					}
+				}
+			}
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+	return sorted, nil
+}
+
+func compareWithLess[T any](a, b T, less func(a, b T) bool) int {
+	switch {
+	case less(a, b):
+		return -1
+	case less(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareNil orders missing values last regardless of direction.
+func compareNil(a, b any) (int, bool) {
+	switch {
+	case a == nil && b == nil:
+		return 0, true
+	case a == nil:
+		return 1, true
+	case b == nil:
+		return -1, true
+	default:
+		return 0, false
+	}
+}
+
+func compareValues(a, b any, opts *Options) int {
+	if x, ok := numericValue(a); ok {
+		if y, ok := numericValue(b); ok {
+			return compareOrdered(x, y)
+		}
+	}
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok {
+			return compareOrdered(boolRank(x), boolRank(y))
+		}
+	}
+	return compareStrings(sortText(a), sortText(b), opts)
+}
+
+// compareColumnValues orders types with a sort key, such as IP addresses, by
+// that key and everything else by compareValues.
+func compareColumnValues(colType ColumnType, a, b any, opts *Options) int {
+	if x, ok := cellSortKey(colType, a); ok {
+		if y, ok := cellSortKey(colType, b); ok {
+			return strings.Compare(x, y)
+		}
+	}
+	return compareValues(a, b, opts)
+}
+
+func sortText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return stringifyValue(value)
+	}
+}
+
+func boolRank(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func compareOrdered[V int | float64 | string](a, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareStrings(a, b string, opts *Options) int {
+	if opts.NaturalSort {
+		return compareNatural(a, b, opts.Collator)
+	}
+	return compareText(a, b, opts.Collator)
+}
+
+func compareText(a, b string, collator Collator) int {
+	if collator != nil {
+		return collator.CompareString(a, b)
+	}
+	return compareOrdered(a, b)
+}
+
+// compareNatural compares runs of ASCII digits by numeric value so that
+// "item2" sorts before "item10".
+func compareNatural(a, b string, collator Collator) int {
+	for a != "" && b != "" {
+		chunkA, restA := splitNaturalChunk(a)
+		chunkB, restB := splitNaturalChunk(b)
+		var cmp int
+		if isDigit(chunkA[0]) && isDigit(chunkB[0]) {
+			cmp = compareDigits(chunkA, chunkB)
+		} else {
+			cmp = compareText(chunkA, chunkB, collator)
+		}
+		if cmp != 0 {
+			return cmp
+		}
+		a, b = restA, restB
+	}
+	return compareOrdered(len(a), len(b))
+}
+
+func splitNaturalChunk(text string) (string, string) {
+	digits := isDigit(text[0])
+	end := 1
+	for end < len(text) && isDigit(text[end]) == digits {
+		end += 1
+	}
+	return text[:end], text[end:]
+}
+
+func compareDigits(a, b string) int {
+	trimmedA := strings.TrimLeft(a, "0")
+	trimmedB := strings.TrimLeft(b, "0")
+	if cmp := compareOrdered(len(trimmedA), len(trimmedB)); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareOrdered(trimmedA, trimmedB); cmp != 0 {
+		return cmp
+	}
+	return compareOrdered(len(a), len(b))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
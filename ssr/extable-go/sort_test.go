@@ -0,0 +1,77 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+type foldCollator struct{}
+
+func (foldCollator) CompareString(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func renderedOrder(t *testing.T, html string, values ...string) {
+	t.Helper()
+	last := -1
+	for _, value := range values {
+		index := strings.Index(html, ">"+value+"</td>")
+		if index < 0 || index < last {
+			t.Fatalf("expected order %v: %s", values, html)
+		}
+		last = index
+	}
+}
+
+func TestSortNatural(t *testing.T) {
+	data := []sampleRow{{Name: "item10"}, {Name: "item2"}, {Name: "item1"}}
+	result, err := RenderTableHTML(data,
+		Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}},
+		Options{Sort: []SortSpec{{Key: "name"}}, NaturalSort: true},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	renderedOrder(t, result.HTML, "item1", "item2", "item10")
+	if data[0].Name != "item10" {
+		t.Fatalf("expected input slice untouched")
+	}
+}
+
+func TestSortCollatorAndDescending(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "age", Type: ColumnTypeInt},
+	}}
+	data := []sampleRow{{Name: "b", Age: 1}, {Name: "B", Age: 2}, {Name: "a", Age: 3}}
+	result, err := RenderTableHTML(data, schema, Options{Sort: []SortSpec{{Key: "name"}, {Key: "age", Descending: true}}, Collator: foldCollator{}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	renderedOrder(t, result.HTML, "3", "2", "1")
+
+	if _, err := RenderTableHTML(data, schema, Options{Sort: []SortSpec{{Key: "missing"}}}); err == nil {
+		t.Fatalf("expected unknown sort key error")
+	}
+}
+
+type severityRow struct {
+	Severity string `json:"severity"`
+}
+
+func TestSortCustomLess(t *testing.T) {
+	rank := map[string]int{"low": 0, "medium": 1, "high": 2}
+	result, err := RenderTableHTML(
+		[]severityRow{{Severity: "medium"}, {Severity: "high"}, {Severity: "low"}},
+		Schema[severityRow]{Columns: []Column[severityRow]{{
+			Key:  "severity",
+			Type: ColumnTypeString,
+			Less: func(a, b severityRow) bool { return rank[a.Severity] < rank[b.Severity] },
+		}}},
+		Options{Sort: []SortSpec{{Key: "severity", Descending: true}}},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	renderedOrder(t, result.HTML, "high", "medium", "low")
+}
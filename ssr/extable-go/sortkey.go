@@ -0,0 +1,17 @@
+package extable
+
+// cellSortKey returns the data-value emitted for column types whose display
+// text does not sort correctly as a string. Keys compare lexically.
+func cellSortKey(colType ColumnType, value any) (string, bool) {
+	switch colType {
+	case ColumnTypeIP:
+		if prefix, ok := ipValue(value); ok {
+			return ipSortKey(prefix), true
+		}
+	case ColumnTypeSemver:
+		if version, ok := semverValue(value); ok {
+			return version.sortKey(), true
+		}
+	}
+	return "", false
+}
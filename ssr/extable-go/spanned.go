@@ -0,0 +1,25 @@
+package extable
+
+import "strconv"
+
+func (r *tableRenderer[T]) renderSpannedCell(builder *htmlBuilder, colIndex int, cell SpannedCell, node *treeNode) int {
+	span := cell.ColSpan
+	if span < 1 {
+		span = 1
+	}
+	if remaining := len(r.columns) - colIndex; span > remaining {
+		span = remaining
+	}
+	attrs := []string{"class", "extable-cell extable-spanned extable-readonly", "data-col-key", r.columns[colIndex].Key}
+	if span > 1 {
+		attrs = append(attrs, "colspan", strconv.Itoa(span))
+	}
+	attrs = append(attrs, r.navAttrs(colIndex)...)
+	builder.openTag("td", attrs...)
+	if node != nil && colIndex == 0 {
+		renderTreeExpander(builder, *node)
+	}
+	builder.raw(string(cell.HTML))
+	builder.closeTag("td")
+	return span
+}
@@ -0,0 +1,30 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpannedCell(t *testing.T) {
+	schema := Schema[exportRow]{Columns: []Column[exportRow]{
+		{Key: "id", Type: ColumnTypeInt},
+		{Key: "name", Type: ColumnTypeString, Virtual: true, Formula: func(row exportRow) any {
+			if row.ID == 0 {
+				return SpannedCell{HTML: "<em>archived below</em>", ColSpan: 5}
+			}
+			return row.Name
+		}},
+		{Key: "active", Type: ColumnTypeBoolean},
+	}}
+	result, err := RenderTableHTML([]exportRow{{ID: 1, Name: "a"}, {ID: 0}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := `<td class="extable-cell extable-spanned extable-readonly" data-col-key="name" colspan="2"><em>archived below</em></td></tr>`
+	if !strings.Contains(result.HTML, want) {
+		t.Fatalf("missing spanned cell: %s", result.HTML)
+	}
+	if strings.Count(result.HTML, `data-col-key="active"`) != 2 {
+		t.Fatalf("the covered column should be skipped only in the spanned row: %s", result.HTML)
+	}
+}
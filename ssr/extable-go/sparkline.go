@@ -0,0 +1,146 @@
+package extable
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSparklineWidth  = 80
+	defaultSparklineHeight = 20
+)
+
+func sparklineValues(value any) ([]float64, bool) {
+	switch v := value.(type) {
+	case []float64:
+		return v, true
+	case []float32:
+		values := make([]float64, len(v))
+		for i, item := range v {
+			values[i] = float64(item)
+		}
+		return values, true
+	case []int:
+		values := make([]float64, len(v))
+		for i, item := range v {
+			values[i] = float64(item)
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+func renderSparkline(builder *htmlBuilder, values []float64, spec *SparklineSpec) {
+	kind := SparklineLine
+	width := defaultSparklineWidth
+	height := defaultSparklineHeight
+	if spec != nil {
+		if spec.Kind != "" {
+			kind = spec.Kind
+		}
+		if spec.Width > 0 {
+			width = spec.Width
+		}
+		if spec.Height > 0 {
+			height = spec.Height
+		}
+	}
+	values = finiteValues(values)
+	labels := make([]string, len(values))
+	for i, v := range values {
+		labels[i] = formatFloat(v, -1)
+	}
+	builder.openTag("svg",
+		"class", "extable-sparkline extable-sparkline-"+string(kind),
+		"width", strconv.Itoa(width),
+		"height", strconv.Itoa(height),
+		"viewBox", "0 0 "+strconv.Itoa(width)+" "+strconv.Itoa(height),
+		"role", "img",
+		"aria-label", strings.Join(labels, ", "),
+	)
+	if len(values) > 0 {
+		if kind == SparklineBar {
+			renderSparklineBars(builder, values, float64(width), float64(height))
+		} else {
+			renderSparklineLine(builder, values, float64(width), float64(height))
+		}
+	}
+	builder.closeTag("svg")
+}
+
+func renderSparklineLine(builder *htmlBuilder, values []float64, width, height float64) {
+	lo, hi := sparklineRange(values, false)
+	step := 0.0
+	if len(values) > 1 {
+		step = width / float64(len(values)-1)
+	}
+	points := make([]string, len(values))
+	for i, v := range values {
+		points[i] = svgNumber(float64(i)*step) + "," + svgNumber(sparklineY(v, lo, hi, height))
+	}
+	builder.openTag("polyline",
+		"points", strings.Join(points, " "),
+		"fill", "none",
+		"stroke", "currentColor",
+		"stroke-width", "1",
+	)
+	builder.closeTag("polyline")
+}
+
+func renderSparklineBars(builder *htmlBuilder, values []float64, width, height float64) {
+	lo, hi := sparklineRange(values, true)
+	slot := width / float64(len(values))
+	barWidth := math.Max(slot-1, 1)
+	baseline := sparklineY(0, lo, hi, height)
+	for i, v := range values {
+		y := sparklineY(v, lo, hi, height)
+		top := math.Min(y, baseline)
+		builder.openTag("rect",
+			"x", svgNumber(float64(i)*slot),
+			"y", svgNumber(top),
+			"width", svgNumber(barWidth),
+			"height", svgNumber(math.Abs(baseline-y)),
+			"fill", "currentColor",
+		)
+		builder.closeTag("rect")
+	}
+}
+
+// finiteValues drops NaN and ±Inf points, which would otherwise poison the
+// range and emit NaN coordinates.
+func finiteValues(values []float64) []float64 {
+	finite := make([]float64, 0, len(values))
+	for _, v := range values {
+		if isFinite(v) {
+			finite = append(finite, v)
+		}
+	}
+	return finite
+}
+
+func sparklineRange(values []float64, includeZero bool) (float64, float64) {
+	lo := values[0]
+	hi := values[0]
+	for _, v := range values[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if includeZero {
+		lo = math.Min(lo, 0)
+		hi = math.Max(hi, 0)
+	}
+	return lo, hi
+}
+
+func sparklineY(value, lo, hi, height float64) float64 {
+	if hi == lo {
+		return height / 2
+	}
+	return height - (value-lo)/(hi-lo)*height
+}
+
+func svgNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
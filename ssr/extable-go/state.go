@@ -0,0 +1,50 @@
+package extable
+
+import "encoding/json"
+
+type State struct {
+	Columns []ColumnState `json:"columns"`
+}
+
+type ColumnState struct {
+	Key            string     `json:"key"`
+	Type           ColumnType `json:"type"`
+	Header         string     `json:"header,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	Readonly       bool       `json:"readonly,omitempty"`
+	ReadonlyReason string     `json:"readonlyReason,omitempty"`
+	AllowedValues  []string   `json:"allowedValues,omitempty"`
+}
+
+func buildState[T any](columns []Column[T]) State {
+	state := State{Columns: make([]ColumnState, 0, len(columns))}
+	for _, col := range columns {
+		colState := ColumnState{
+			Key:         col.Key,
+			Type:        col.Type,
+			Header:      col.Header,
+			Description: col.Description,
+			Readonly:    col.Readonly || col.derived(),
+		}
+		if colState.Readonly {
+			colState.ReadonlyReason = col.ReadonlyReason
+		}
+		if col.Enum != nil && (col.Type == ColumnTypeEnum || col.Type == ColumnTypeEnumSet) {
+			colState.AllowedValues = col.Enum.allowedValues()
+		}
+		state.Columns = append(state.Columns, colState)
+	}
+	return state
+}
+
+func renderState[T any](builder *htmlBuilder, columns []Column[T]) error {
+	// json.Marshal escapes <, >, and & so the payload cannot close the script element.
+	payload, err := json.Marshal(buildState(columns))
+	if err != nil {
+		return err
+	}
+	builder.openTag("script", "type", "application/json", "class", "extable-state")
+	builder.raw(string(payload))
+	builder.closeTag("script")
+	return nil
+}
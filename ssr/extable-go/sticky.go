@@ -0,0 +1,60 @@
+package extable
+
+import "strconv"
+
+// stickyStyle returns the inline position:sticky declarations for a header
+// cell. headRow is the cell's row within the thead, or -1 in the body.
+// Logical inset-inline-start keeps the row header on the leading edge in
+// both LTR and RTL tables; the corner stacks above both sticky bands.
+func stickyStyle(opts *Options, headRow int, rowHeader bool) map[string]string {
+	top := opts.frozenHeaderRow(headRow)
+	start := rowHeader && opts.StickyRowHeader
+	if !top && !start {
+		return nil
+	}
+	style := map[string]string{"position": "sticky"}
+	if top {
+		style["top"] = headerRowTop(headRow)
+		style["z-index"] = "2"
+	}
+	if start {
+		style["inset-inline-start"] = "0"
+		style["z-index"] = "1"
+	}
+	if top && start {
+		style["z-index"] = "3"
+	}
+	return style
+}
+
+func stickyClasses(opts *Options, headRow int, rowHeader bool) []string {
+	var classes []string
+	if opts.frozenHeaderRow(headRow) {
+		classes = append(classes, "extable-sticky-header")
+	}
+	if rowHeader && opts.StickyRowHeader {
+		classes = append(classes, "extable-sticky-row-header")
+	}
+	return classes
+}
+
+// frozenHeaderRow reports whether thead row headRow is pinned: the first
+// FrozenHeaderRows rows when it is set, otherwise every row with StickyHeader.
+func (o *Options) frozenHeaderRow(headRow int) bool {
+	if headRow < 0 {
+		return false
+	}
+	if o.FrozenHeaderRows > 0 {
+		return headRow < o.FrozenHeaderRows
+	}
+	return o.StickyHeader
+}
+
+// headerRowTop offsets stacked sticky rows by the height of the rows above,
+// which stylesheets set through --extable-header-row-height.
+func headerRowTop(headRow int) string {
+	if headRow == 0 {
+		return "0"
+	}
+	return "calc(var(--extable-header-row-height, 2.5em) * " + strconv.Itoa(headRow) + ")"
+}
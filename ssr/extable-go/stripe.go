@@ -0,0 +1,19 @@
+package extable
+
+func (r *tableRenderer[T]) stripeClass() string {
+	stripe := r.opts.Stripe
+	if stripe == nil || r.rowHidden() {
+		return ""
+	}
+	every := stripe.Every
+	if every <= 0 {
+		every = 1
+	}
+	if (r.band/every)%2 == 0 {
+		return ""
+	}
+	if stripe.Class == "" {
+		return "extable-stripe"
+	}
+	return stripe.Class
+}
@@ -0,0 +1,91 @@
+package extable
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TableSpec renders the rows of a ColumnTypeTable cell; build one with NestedTable.
+type TableSpec struct {
+	render func(value any, parent *Options, idPrefix string) (Result, bool, error)
+	len    func(value any) (int, bool)
+	err    error
+}
+
+// NestedTable renders cell values of type []E as a table with its own schema.
+// The nested table inherits Messages, Locale, Location, URLPolicy, Sanitizer
+// and ClassPrefix from the outer render, and an IDPrefix derived from the
+// cell; other options do not apply.
+func NestedTable[E any](schema Schema[E]) *TableSpec {
+	renderer, err := NewRenderer(schema, Options{})
+	if err != nil {
+		return &TableSpec{err: err}
+	}
+	return &TableSpec{
+		render: func(value any, parent *Options, idPrefix string) (Result, bool, error) {
+			rows, ok := value.([]E)
+			if !ok {
+				return Result{}, false, nil
+			}
+			opts := Options{
+				Messages:    parent.Messages,
+				Locale:      parent.Locale,
+				Location:    parent.Location,
+				URLPolicy:   parent.URLPolicy,
+				Sanitizer:   parent.Sanitizer,
+				ClassPrefix: parent.ClassPrefix,
+				IDPrefix:    idPrefix,
+			}
+			result, err := renderer.render(opts, rows)
+			return result, true, err
+		},
+		len: func(value any) (int, bool) {
+			rows, ok := value.([]E)
+			return len(rows), ok
+		},
+	}
+}
+
+func (r *tableRenderer[T]) renderNestedTable(builder *htmlBuilder, rowIndex int, col Column[T], value any, text string) {
+	// Each nested table needs its own id prefix, or every row would repeat the same ids.
+	idPrefix := r.opts.idPrefix() + "-" + strconv.Itoa(rowIndex) + "-" + col.Key
+	result, ok, err := col.Table.render(value, r.opts, idPrefix)
+	if err != nil {
+		r.warn(rowIndex, col.Key, "nested table: "+err.Error())
+		ok = false
+	}
+	if !ok {
+		r.text(builder, text)
+		return
+	}
+	for _, w := range result.Metadata.Warnings {
+		r.warn(rowIndex, col.Key, fmt.Sprintf("nested row %d, column %q: %s", w.RowIndex, w.ColKey, w.Message))
+	}
+	builder.openTag("div", "class", "extable-nested")
+	builder.raw(result.HTML)
+	builder.closeTag("div")
+}
+
+// formatNestedTable is the text of a nested table cell outside HTML, such as in exports: its row count.
+func formatNestedTable(value any, spec *TableSpec) (string, bool) {
+	if spec == nil || spec.len == nil {
+		return "", false
+	}
+	n, ok := spec.len(value)
+	if !ok {
+		return "", false
+	}
+	return strconv.Itoa(n), true
+}
+
+func checkTableSpecs[T any](columns []Column[T]) error {
+	for _, col := range columns {
+		if col.Type == ColumnTypeTable && col.Table == nil {
+			return &SchemaError{ColKey: col.Key, Message: "has type table but no Table"}
+		}
+		if col.Table != nil && col.Table.err != nil {
+			return &SchemaError{ColKey: col.Key, Message: "has an invalid nested schema", Err: col.Table.err}
+		}
+	}
+	return nil
+}
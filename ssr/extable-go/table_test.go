@@ -0,0 +1,56 @@
+package extable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type lineItem struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+type order struct {
+	ID    string     `json:"id"`
+	Items []lineItem `json:"items"`
+}
+
+func TestNestedTable(t *testing.T) {
+	items := NestedTable(Schema[lineItem]{Columns: []Column[lineItem]{
+		{Key: "sku", Type: ColumnTypeString},
+		{Key: "qty", Type: ColumnTypeInt},
+	}})
+	schema := Schema[order]{Columns: []Column[order]{
+		{Key: "id", Type: ColumnTypeString},
+		{Key: "items", Type: ColumnTypeTable, Table: items},
+	}}
+	rows := []order{{ID: "A-1", Items: []lineItem{{SKU: "pen", Qty: 2}, {SKU: "ink", Qty: 1}}}}
+	result, err := RenderTableHTML(rows, schema, Options{ClassPrefix: "x-"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	if !strings.Contains(html, `<div class="x-nested"><table>`) || strings.Count(html, "<table") != 2 {
+		t.Fatalf("missing nested table: %s", html)
+	}
+	if !strings.Contains(html, `data-col-key="sku">ink</td>`) || !strings.Contains(html, "x-readonly") {
+		t.Fatalf("nested rows not rendered: %s", html)
+	}
+	if text := formatValue(any(rows[0].Items), schema.Columns[1], &Options{}); text != "2" {
+		t.Fatalf("text should be the row count, got %q", text)
+	}
+}
+
+func TestNestedTableSchemaErrors(t *testing.T) {
+	schema := Schema[order]{Columns: []Column[order]{{Key: "items", Type: ColumnTypeTable}}}
+	if err := schema.Validate(); err == nil || !strings.Contains(err.Error(), "no Table") {
+		t.Fatalf("expected missing Table error, got %v", err)
+	}
+	schema.Columns[0].Table = NestedTable(Schema[lineItem]{Columns: []Column[lineItem]{{Key: "qty", Type: ColumnTypeInt, Expr: "qty +"}}})
+	_, err := RenderTableHTML(nil, schema, Options{})
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || schemaErr.ColKey != "items" || !strings.Contains(err.Error(), "invalid nested schema") {
+		t.Fatalf("expected nested schema error, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package extable
+
+import (
+	"strings"
+	"text/template"
+)
+
+// textTemplates caches parsed Column.TextTemplate sources so each distinct
+// template is parsed once rather than once per cell.
+var textTemplates = newLRUCache[*template.Template](maxCompiledSources)
+
+func parseTextTemplate(source string) (*template.Template, error) {
+	if cached, ok := textTemplates.get(source); ok {
+		return cached, nil
+	}
+	tmpl, err := template.New("column").Option("missingkey=zero").Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	textTemplates.add(source, tmpl)
+	return tmpl, nil
+}
+
+func checkTextTemplates[T any](columns []Column[T]) error {
+	for _, col := range columns {
+		if col.TextTemplate == "" {
+			continue
+		}
+		if _, err := parseTextTemplate(col.TextTemplate); err != nil {
+			return &SchemaError{ColKey: col.Key, Message: "text template", Err: err}
+		}
+	}
+	return nil
+}
+
+func executeTextTemplate(source string, row any) (string, bool) {
+	tmpl, err := parseTextTemplate(source)
+	if err != nil {
+		return "", false
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, row); err != nil {
+		return "", false
+	}
+	return sb.String(), true
+}
@@ -0,0 +1,43 @@
+package extable
+
+import (
+	"strings"
+	"testing"
+)
+
+type nameRow struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+}
+
+func TestTextTemplateColumn(t *testing.T) {
+	schema := Schema[nameRow]{Columns: []Column[nameRow]{
+		{Key: "full", Type: ColumnTypeString, TextTemplate: "{{.First}} {{.Last}}"},
+	}}
+	rows := []nameRow{{First: "Ada", Last: "<Lovelace>"}, {First: "Alan", Last: "Turing"}}
+	result, err := RenderTableHTML(rows, schema, Options{Sort: []SortSpec{{Key: "full", Descending: true}}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, ">Ada &lt;Lovelace&gt;</td>") {
+		t.Fatalf("expected escaped template output, got %s", result.HTML)
+	}
+	if strings.Index(result.HTML, "Alan Turing") > strings.Index(result.HTML, "Ada") {
+		t.Fatalf("expected sort by template output, got %s", result.HTML)
+	}
+}
+
+func TestTextTemplateErrors(t *testing.T) {
+	schema := Schema[nameRow]{Columns: []Column[nameRow]{{Key: "full", Type: ColumnTypeString, TextTemplate: "{{.First"}}}
+	if _, err := RenderTableHTML([]nameRow{}, schema, Options{}); err == nil {
+		t.Fatalf("expected parse error")
+	}
+	schema.Columns[0].TextTemplate = "{{.Missing}}"
+	result, err := RenderTableHTML([]nameRow{{First: "Ada"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if len(result.Metadata.Warnings) != 1 || result.Metadata.Warnings[0].Message != "text template failed" {
+		t.Fatalf("expected execution warning, got %+v", result.Metadata.Warnings)
+	}
+}
@@ -0,0 +1,26 @@
+package extable
+
+import (
+	"context"
+	"time"
+)
+
+// RenderTableHTMLContext renders like RenderTableHTML but stops writing rows
+// when ctx is done, returning the partial table with Metadata.TimedOut set.
+// It returns an error only for the problems RenderTableHTML reports.
+func RenderTableHTMLContext[T any](ctx context.Context, data []T, schema Schema[T], opts Options) (Result, error) {
+	opts.ctx = ctx
+	return RenderTableHTML(data, schema, opts)
+}
+
+func (r *tableRenderer[T]) expired() bool {
+	if r.timedOut {
+		return true
+	}
+	if !r.deadline.IsZero() && !time.Now().Before(r.deadline) {
+		r.timedOut = true
+	} else if r.opts.ctx != nil && r.opts.ctx.Err() != nil {
+		r.timedOut = true
+	}
+	return r.timedOut
+}
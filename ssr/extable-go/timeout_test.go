@@ -0,0 +1,57 @@
+package extable
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderTimeout(t *testing.T) {
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "slow", Type: ColumnTypeString, Virtual: true, Formula: func(sampleRow) any {
+			time.Sleep(5 * time.Millisecond)
+			return "x"
+		}},
+	}}
+	rows := make([]sampleRow, 200)
+	result, err := RenderTableHTML(rows, schema, Options{Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !result.Metadata.TimedOut || result.Metadata.RowCount == 0 || result.Metadata.RowCount >= len(rows) {
+		t.Fatalf("expected a partial render, got %+v", result.Metadata)
+	}
+	if !strings.HasSuffix(result.HTML, "</tbody></table>") {
+		t.Fatalf("expected a closed table, got %s", result.HTML[len(result.HTML)-40:])
+	}
+
+	complete, err := RenderTableHTML(rows[:2], schema, Options{Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if complete.Metadata.TimedOut || complete.Metadata.RowCount != 2 {
+		t.Fatalf("unexpected metadata: %+v", complete.Metadata)
+	}
+
+	if _, err := RenderTableHTML(rows, schema, Options{Timeout: -time.Second}); err == nil {
+		t.Fatalf("expected negative timeout to be rejected")
+	}
+}
+
+func TestRenderTableHTMLContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	schema := Schema[sampleRow]{Columns: []Column[sampleRow]{{Key: "name", Type: ColumnTypeString}}}
+	result, err := RenderTableHTMLContext(ctx, []sampleRow{{Name: "a"}}, schema, Options{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !result.Metadata.TimedOut || result.Metadata.RowCount != 0 {
+		t.Fatalf("unexpected metadata: %+v", result.Metadata)
+	}
+	if strings.Contains(result.HTML, "extable-empty") {
+		t.Fatalf("a cancelled render must not claim the table is empty: %s", result.HTML)
+	}
+}
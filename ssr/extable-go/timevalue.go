@@ -0,0 +1,91 @@
+package extable
+
+import (
+	"math"
+	"time"
+)
+
+// timeValue coerces the values accepted by date and time columns into a
+// time.Time. Integers are read as Unix timestamps in the unit given by
+// Format.Epoch, and durations as an offset from midnight.
+func timeValue(value any, format *Format) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case time.Duration:
+		return time.Time{}.Add(v), true
+	case string:
+		return parseTimeString(v, format)
+	case float32, float64:
+		number, _ := numericValue(v)
+		return epochTime(int64(number), format), true
+	}
+	if n, ok := integerValue(value); ok {
+		return epochTime(n, format), true
+	}
+	return time.Time{}, false
+}
+
+// defaultParseLayouts are tried after Format.ParseLayouts when a date or time
+// column receives a string.
+var defaultParseLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"15:04:05",
+	"15:04",
+}
+
+func parseTimeString(text string, format *Format) (time.Time, bool) {
+	if format != nil {
+		for _, layout := range format.ParseLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				return t, true
+			}
+		}
+	}
+	for _, layout := range defaultParseLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func epochTime(n int64, format *Format) time.Time {
+	if format != nil && format.Epoch == EpochMilliseconds {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
+
+func integerValue(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), uint64(v) <= math.MaxInt64
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,69 @@
+package extable
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type eventRow struct {
+	At      int64         `json:"at"`
+	AtMilli int64         `json:"atMilli"`
+	Opens   time.Duration `json:"opens"`
+}
+
+func TestEpochAndDurationCoercion(t *testing.T) {
+	result, err := RenderTableHTML(
+		[]eventRow{{At: 1709251200, AtMilli: 1709251200123, Opens: 9*time.Hour + 30*time.Minute}},
+		Schema[eventRow]{Columns: []Column[eventRow]{
+			{Key: "at", Type: ColumnTypeDateTime},
+			{Key: "atMilli", Type: ColumnTypeDateTime, Format: &Format{Epoch: EpochMilliseconds, DateTimeLayout: "2006-01-02 15:04:05.000"}},
+			{Key: "opens", Type: ColumnTypeTime, Format: &Format{TimeLayout: "15:04"}},
+		}},
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{">2024-03-01 00:00:00<", ">2024-03-01 00:00:00.123<", ">09:30<"} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s: %s", want, result.HTML)
+		}
+	}
+}
+
+type textDateRow struct {
+	When string `json:"when"`
+}
+
+func TestStringDateParseLayouts(t *testing.T) {
+	schema := Schema[textDateRow]{Columns: []Column[textDateRow]{
+		{Key: "when", Type: ColumnTypeDate, Format: &Format{ParseLayouts: []string{"02/01/2006"}, DateLayout: "Jan 2, 2006"}},
+	}}
+	result, err := RenderTableHTML(
+		[]textDateRow{{When: "05/03/2024"}, {When: "2024-03-06"}, {When: "Thu, 07 Mar 2024 10:00:00 GMT"}, {When: "soon"}},
+		schema,
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{">Mar 5, 2024<", ">Mar 6, 2024<", ">Mar 7, 2024<", ">soon<"} {
+		if !strings.Contains(result.HTML, want) {
+			t.Fatalf("expected %s: %s", want, result.HTML)
+		}
+	}
+}
+
+func TestIntegerValueOverflow(t *testing.T) {
+	if _, ok := integerValue(uint64(math.MaxInt64) + 1); ok {
+		t.Fatalf("uint64 above MaxInt64 should not convert")
+	}
+	if _, ok := timeValue(uint(math.MaxUint64), nil); ok {
+		t.Fatalf("overflowing uint should not become a date")
+	}
+	if n, ok := integerValue(uint64(math.MaxInt64)); !ok || n != math.MaxInt64 {
+		t.Fatalf("MaxInt64 should convert, got %d %v", n, ok)
+	}
+}
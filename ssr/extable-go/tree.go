@@ -0,0 +1,86 @@
+package extable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// maxTreeDepth bounds Schema.Children nesting when rows cannot be identified
+// by RowID or pointer, so a cycle fails instead of overflowing the stack.
+const maxTreeDepth = 256
+
+// treeNode records where a flattened row sits in a Schema.Children hierarchy.
+type treeNode struct {
+	depth       int
+	hasChildren bool
+}
+
+// flattenTree expands each row's children depth-first, sorting every level
+// with the same Options.Sort as the top level. A row that is its own
+// ancestor, by RowID or pointer identity, fails with ErrTreeCycle.
+func flattenTree[T any](data []T, schema Schema[T], getter *fieldGetter, opts *Options) ([]T, []treeNode, error) {
+	rows := make([]T, 0, len(data))
+	nodes := make([]treeNode, 0, len(data))
+	ancestors := make(map[any]bool)
+	var walk func(level []T, depth int) error
+	walk = func(level []T, depth int) error {
+		if depth > maxTreeDepth {
+			return fmt.Errorf("%w: nesting exceeds %d levels", ErrTreeCycle, maxTreeDepth)
+		}
+		for _, row := range level {
+			id, identified := treeIdentity(row, schema.RowID)
+			if identified && ancestors[id] {
+				return fmt.Errorf("%w at row %v", ErrTreeCycle, id)
+			}
+			children := schema.Children(row)
+			rows = append(rows, row)
+			nodes = append(nodes, treeNode{depth: depth, hasChildren: len(children) > 0})
+			if len(children) == 0 {
+				continue
+			}
+			sorted, err := sortRows(children, schema.Columns, getter, opts)
+			if err != nil {
+				return err
+			}
+			if identified {
+				ancestors[id] = true
+			}
+			if err := walk(sorted, depth+1); err != nil {
+				return err
+			}
+			delete(ancestors, id)
+		}
+		return nil
+	}
+	if err := walk(data, 0); err != nil {
+		return nil, nil, err
+	}
+	return rows, nodes, nil
+}
+
+func treeIdentity[T any](row T, rowID func(T) string) (any, bool) {
+	if rowID != nil {
+		return rowID(row), true
+	}
+	if rv := reflect.ValueOf(row); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return rv.Pointer(), true
+	}
+	return nil, false
+}
+
+func treeRowClasses(node treeNode) []string {
+	return []string{"extable-tree-row", "extable-tree-depth-" + strconv.Itoa(node.depth)}
+}
+
+// renderTreeExpander writes the toggle for rows with children, or a spacer
+// that keeps leaf rows aligned with their siblings.
+func renderTreeExpander(builder *htmlBuilder, node treeNode) {
+	if node.hasChildren {
+		builder.openTag("button", "class", "extable-tree-toggle", "type", "button", "aria-expanded", "true")
+		builder.closeTag("button")
+		return
+	}
+	builder.openTag("span", "class", "extable-tree-spacer")
+	builder.closeTag("span")
+}
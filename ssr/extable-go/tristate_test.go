@@ -0,0 +1,48 @@
+package extable
+
+import (
+	"database/sql"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestTristateBoolean(t *testing.T) {
+	type flags struct {
+		Opt    *bool        `json:"opt"`
+		Synced sql.NullBool `json:"synced"`
+	}
+	yes := true
+	schema := Schema[flags]{Columns: []Column[flags]{
+		{Key: "opt", Type: ColumnTypeBoolean, Format: &Format{BooleanUnknown: "unknown"}},
+		{Key: "synced", Type: ColumnTypeBoolean},
+	}}
+	rows := []flags{{Opt: &yes, Synced: sql.NullBool{Bool: false, Valid: true}}, {}}
+	result, err := RenderTableHTML(rows, schema, Options{Messages: &Messages{BooleanUnknown: "n/a"}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	html := result.HTML
+	if !strings.Contains(html, `data-col-key="opt">true</td>`) || !strings.Contains(html, `data-col-key="synced">false</td>`) {
+		t.Fatalf("known values should render normally: %s", html)
+	}
+	if strings.Count(html, "extable-boolean-unknown") != 2 || !strings.Contains(html, `">unknown</td>`) || !strings.Contains(html, `">n/a</td>`) {
+		t.Fatalf("expected unknown labels: %s", html)
+	}
+
+	schema.Columns[0].Format.BooleanTristate = true
+	form, err := RenderTableHTML(rows[1:], schema, Options{FormMode: &FormMode{}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(form.HTML, `<select class="extable-input" name="cell[0][opt]" aria-label="opt"><option value="" selected="">unknown</option><option value="true">true</option><option value="false">false</option></select>`) {
+		t.Fatalf("missing tristate select: %s", form.HTML)
+	}
+	if !strings.Contains(form.HTML, `name="cell[0][synced]" value="true" aria-label="synced" data-indeterminate="">`) {
+		t.Fatalf("missing indeterminate checkbox marker: %s", form.HTML)
+	}
+	edits, warnings := DecodeFormEdits(url.Values{"cell[0][opt]": {""}}, schema)
+	if len(warnings) != 0 || len(edits) != 1 || edits[0].Value != nil {
+		t.Fatalf("unknown should decode as nil: %+v %v", edits, warnings)
+	}
+}
@@ -3,49 +3,250 @@ package extable
 type ColumnType string
 
 const (
-	ColumnTypeString   ColumnType = "string"
-	ColumnTypeNumber   ColumnType = "number"
-	ColumnTypeInt      ColumnType = "int"
-	ColumnTypeUint     ColumnType = "uint"
-	ColumnTypeBoolean  ColumnType = "boolean"
-	ColumnTypeDate     ColumnType = "date"
-	ColumnTypeTime     ColumnType = "time"
-	ColumnTypeDateTime ColumnType = "datetime"
-	ColumnTypeEnum     ColumnType = "enum"
-	ColumnTypeTags     ColumnType = "tags"
-	ColumnTypeButton   ColumnType = "button"
-	ColumnTypeLink     ColumnType = "link"
+	ColumnTypeString    ColumnType = "string"
+	ColumnTypeNumber    ColumnType = "number"
+	ColumnTypeInt       ColumnType = "int"
+	ColumnTypeUint      ColumnType = "uint"
+	ColumnTypeBoolean   ColumnType = "boolean"
+	ColumnTypeDate      ColumnType = "date"
+	ColumnTypeTime      ColumnType = "time"
+	ColumnTypeDateTime  ColumnType = "datetime"
+	ColumnTypeEnum      ColumnType = "enum"
+	ColumnTypeEnumSet   ColumnType = "enumset"
+	ColumnTypeTags      ColumnType = "tags"
+	ColumnTypeButton    ColumnType = "button"
+	ColumnTypeLink      ColumnType = "link"
+	ColumnTypeImage     ColumnType = "image"
+	ColumnTypeRichText  ColumnType = "richtext"
+	ColumnTypeSparkline ColumnType = "sparkline"
+	ColumnTypeFile      ColumnType = "file"
+	// ColumnTypePeriod renders time values as the ISO week, month, quarter or
+	// year containing them, per Column.Period.
+	ColumnTypePeriod ColumnType = "period"
+	// ColumnTypeGeo renders GeoPoint values as coordinates, optionally linked to a map.
+	ColumnTypeGeo ColumnType = "geo"
+	// ColumnTypeIP renders IP addresses and CIDR prefixes from net/netip, net and
+	// string values, with a data-value sort key.
+	ColumnTypeIP ColumnType = "ip"
+	// ColumnTypeSemver renders semantic versions normalized without a leading
+	// "v", with a data-value sort key in precedence order.
+	ColumnTypeSemver ColumnType = "semver"
+	// ColumnTypeTable renders a slice value as a nested table described by Column.Table.
+	ColumnTypeTable ColumnType = "table"
+	// ColumnTypeActions renders Column.Actions as a per-row menu; it reads no field.
+	ColumnTypeActions ColumnType = "actions"
 )
 
 type Schema[T any] struct {
-	Columns []Column[T]
+	Columns []Column[T] `json:"columns"`
+	// Children, when set, renders each row's children beneath it as a collapsible tree.
+	Children func(T) []T `json:"-"`
+	// DetailHTML, when set, adds a hidden full-width detail row after each data row.
+	DetailHTML func(T) SafeHTML `json:"-"`
+	// RowID identifies rows across datasets, for ComputePatch.
+	RowID func(T) string `json:"-"`
+	// RowReadonlyReason explains why a row is locked; a non-empty reason also
+	// makes the row readonly.
+	RowReadonlyReason func(T) string `json:"-"`
+	// DynamicColumns adds columns that depend on the data, such as one per
+	// month present, after Columns. It receives the data as passed in, before
+	// sorting and filtering; RenderHead and DecodeFormEdits, which have no
+	// data, call it with nil.
+	DynamicColumns func(data []T) []Column[T] `json:"-"`
+	// RowFilter, when set, skips rows for which it returns false. It runs
+	// after sorting. A Renderer fixes it at NewRenderer; pass per-viewer
+	// filters to Renderer.RenderView instead.
+	RowFilter func(T) bool `json:"-"`
+	// ColumnVisible, when set, omits columns for which it returns false from
+	// the markup, the emitted state, the schema hash, row hashes, exports
+	// and ComputePatch, and rejects them as Sort and GroupBy keys.
+	// DecodeFormEdits and DecodeMultipartEdits ignore edits to hidden columns.
+	// A Renderer fixes it at NewRenderer; pass per-viewer visibility to
+	// Renderer.RenderView instead.
+	ColumnVisible func(Column[T]) bool `json:"-"`
 }
 
+// ColumnKey is the key type used by constants from cmd/extable-keys. It is an
+// alias, so the constants assign directly to Column.Key.
+type ColumnKey = string
+
 type Column[T any] struct {
-	Key      string
-	Type     ColumnType
-	Header   string
-	Readonly bool
-	Format   *Format
-	Enum     *EnumSpec
-	Tags     *TagsSpec
-	Formula  func(T) any
-	WrapText bool
+	Key      string      `json:"key"`
+	Type     ColumnType  `json:"type"`
+	Header   string      `json:"header,omitempty"`
+	Readonly bool        `json:"readonly,omitempty"`
+	Format   *Format     `json:"format,omitempty"`
+	Enum     *EnumSpec   `json:"enum,omitempty"`
+	Tags     *TagsSpec   `json:"tags,omitempty"`
+	Formula  func(T) any `json:"-"`
+	// Less overrides the value comparison used when sorting by this column.
+	Less      func(a, b T) bool `json:"-"`
+	WrapText  bool              `json:"wrapText,omitempty"`
+	Sparkline *SparklineSpec    `json:"sparkline,omitempty"`
+	Heatmap   *HeatmapSpec      `json:"heatmap,omitempty"`
+	DataBar   *DataBarSpec      `json:"dataBar,omitempty"`
+	// MaxChars truncates string cells to this many characters, ellipsis included; the full text moves to title.
+	MaxChars int `json:"maxChars,omitempty"`
+	// Virtual columns skip field lookup and take their value from Formula alone.
+	Virtual bool `json:"virtual,omitempty"`
+	// TextTemplate is a text/template executed against the row; it replaces the field value.
+	TextTemplate string `json:"textTemplate,omitempty"`
+	// Expr is an expr-lang expression over the row's column keys; it replaces the field value.
+	Expr       string      `json:"expr,omitempty"`
+	ClassRules []ClassRule `json:"classRules,omitempty"`
+	Link       *LinkSpec   `json:"link,omitempty"`
+	Button     *ButtonSpec `json:"button,omitempty"`
+	// Priority ranks columns for responsive hiding; 1 is most important and 0 means never hidden.
+	Priority int `json:"priority,omitempty"`
+	// HeaderIcon is trusted markup shown before the header text.
+	HeaderIcon SafeHTML `json:"-"`
+	// Description explains a terse header; it becomes the abbr title and the th's accessible description.
+	Description string `json:"description,omitempty"`
+	// HeaderAttrs and CellAttrs are added to the column's th and td elements.
+	// Values are escaped; invalid names, and names the renderer already sets,
+	// are dropped with a warning.
+	HeaderAttrs map[string]string `json:"headerAttrs,omitempty"`
+	CellAttrs   map[string]string `json:"cellAttrs,omitempty"`
+	// MaxLines clamps cell content to this many lines with -webkit-line-clamp.
+	MaxLines int         `json:"maxLines,omitempty"`
+	File     *FileSpec   `json:"file,omitempty"`
+	Actions  []Action[T] `json:"actions,omitempty"`
+	// HeaderGroup labels a header row above the column headers; adjacent
+	// columns with the same group share one spanning cell.
+	HeaderGroup string `json:"headerGroup,omitempty"`
+	// ReadonlyReason is shown on the column's readonly cells as a tooltip.
+	ReadonlyReason string      `json:"readonlyReason,omitempty"`
+	Period         *PeriodSpec `json:"period,omitempty"`
+	Geo            *GeoSpec    `json:"geo,omitempty"`
+	Table          *TableSpec  `json:"-"`
+}
+
+// Action is one entry of a ColumnTypeActions menu. ID is emitted as
+// data-action for the client runtime to dispatch on.
+type Action[T any] struct {
+	ID    string   `json:"id"`
+	Label string   `json:"label"`
+	Icon  SafeHTML `json:"-"`
+	// VisibleIf hides the action for rows where it returns false.
+	VisibleIf func(T) bool `json:"-"`
+}
+
+// LinkSpec builds link hrefs from row fields. Href is a template such as
+// "/orders/{order_id}?tab={status}"; the cell value becomes the label.
+type LinkSpec struct {
+	Href   string `json:"href,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// ButtonSpec.Href is a template, as in LinkSpec, emitted as data-href.
+type ButtonSpec struct {
+	Href string `json:"href,omitempty"`
 }
 
 type EnumSpec struct {
-	Labels map[string]string
+	// Values lists the allowed values in display order; when empty the sorted Labels keys are used.
+	Values  []string          `json:"values,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Classes map[string]string `json:"classes,omitempty"`
+	Colors  map[string]string `json:"colors,omitempty"`
+}
+
+type SparklineKind string
+
+const (
+	SparklineLine SparklineKind = "line"
+	SparklineBar  SparklineKind = "bar"
+)
+
+type SparklineSpec struct {
+	Kind   SparklineKind `json:"kind,omitempty"`
+	Width  int           `json:"width,omitempty"`
+	Height int           `json:"height,omitempty"`
+}
+
+type HeatmapSpec struct {
+	// Min and Max fix the gradient bounds; when nil they are computed from all rows passing Schema.RowFilter.
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	LowColor  string   `json:"lowColor,omitempty"`
+	HighColor string   `json:"highColor,omitempty"`
+}
+
+type DataBarSpec struct {
+	// Max is the value drawn as a full-width bar; when nil the largest absolute value in the column is used.
+	Max   *float64 `json:"max,omitempty"`
+	Color string   `json:"color,omitempty"`
 }
 
 type TagsSpec struct {
-	Separator string
+	Separator string            `json:"separator,omitempty"`
+	Classes   map[string]string `json:"classes,omitempty"`
+	Colors    map[string]string `json:"colors,omitempty"`
 }
 
+type EpochUnit string
+
+const (
+	EpochSeconds      EpochUnit = "seconds"
+	EpochMilliseconds EpochUnit = "milliseconds"
+)
+
+type Notation string
+
+const (
+	NotationFixed       Notation = "fixed"
+	NotationScientific  Notation = "scientific"
+	NotationEngineering Notation = "engineering"
+)
+
+type NegativeStyle string
+
+const (
+	NegativeMinus       NegativeStyle = "minus"
+	NegativeParentheses NegativeStyle = "parentheses"
+)
+
+type Rounding string
+
+const (
+	RoundingHalfUp   Rounding = "halfUp"
+	RoundingHalfEven Rounding = "halfEven"
+	RoundingTruncate Rounding = "truncate"
+)
+
 type Format struct {
-	BooleanTrue    string
-	BooleanFalse   string
-	NumberScale    *int
-	DateLayout     string
-	TimeLayout     string
-	DateTimeLayout string
+	BooleanTrue  string `json:"booleanTrue,omitempty"`
+	BooleanFalse string `json:"booleanFalse,omitempty"`
+	// BooleanTruthy enables coercion of strings and integers in boolean columns; listed values (case-insensitive) are true, others false.
+	BooleanTruthy []string `json:"booleanTruthy,omitempty"`
+	// BooleanUnknown labels nil booleans, such as a nil *bool or an invalid
+	// sql.NullBool; Messages.BooleanUnknown (empty by default) when unset.
+	BooleanUnknown string `json:"booleanUnknown,omitempty"`
+	// BooleanTristate renders a true/false/unknown select in FormMode, so
+	// unknown values survive a submission instead of becoming false.
+	BooleanTristate bool   `json:"booleanTristate,omitempty"`
+	NumberScale     *int   `json:"numberScale,omitempty"`
+	DateLayout      string `json:"dateLayout,omitempty"`
+	// DateStyle, when set, takes precedence over DateLayout and the date part of
+	// DateTimeLayout; datetimes keep TimeLayout ("15:04" when empty) after the date.
+	DateStyle      DateStyle `json:"dateStyle,omitempty"`
+	TimeLayout     string    `json:"timeLayout,omitempty"`
+	DateTimeLayout string    `json:"dateTimeLayout,omitempty"`
+	// Custom names a formatter registered with RegisterFormat; it takes precedence over the other fields.
+	Custom string `json:"custom,omitempty"`
+	// Epoch sets how integers in date/time columns are read; seconds when empty.
+	Epoch EpochUnit `json:"epoch,omitempty"`
+	// ParseLayouts are tried in order, before the built-in layouts, when a date or time column receives a string.
+	ParseLayouts []string `json:"parseLayouts,omitempty"`
+	Notation     Notation `json:"notation,omitempty"`
+	// SignificantDigits, when positive, takes precedence over NumberScale.
+	SignificantDigits int           `json:"significantDigits,omitempty"`
+	NegativeStyle     NegativeStyle `json:"negativeStyle,omitempty"`
+	// NegativeClass adds the extable-negative class to cells holding a negative number.
+	NegativeClass bool `json:"negativeClass,omitempty"`
+	// Prefix and Suffix are rendered around numbers in their own spans, outside the formatted value.
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+	// Rounding applies to the decimal representation when NumberScale is set; strconv rounding is used when empty.
+	Rounding Rounding `json:"rounding,omitempty"`
 }
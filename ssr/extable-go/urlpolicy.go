@@ -0,0 +1,131 @@
+package extable
+
+import (
+	"net/url"
+	"strings"
+)
+
+// URLPolicy decides which URLs may be emitted in href and src attributes.
+type URLPolicy struct {
+	// AllowedSchemes lists permitted schemes for absolute URLs, compared
+	// case-insensitively. Relative URLs are always permitted.
+	AllowedSchemes []string
+	// AllowedHosts, when non-empty, restricts URLs that carry a host. An entry
+	// starting with "." also matches any subdomain.
+	AllowedHosts []string
+	// Rewrite runs on every accepted URL and returns the value to emit;
+	// returning "" drops the URL.
+	Rewrite func(u *url.URL) string
+}
+
+var DefaultURLPolicy = &URLPolicy{
+	AllowedSchemes: []string{"http", "https", "mailto", "tel"},
+}
+
+type LinkValue struct {
+	Label  string
+	Href   string
+	Target string
+}
+
+type ImageValue struct {
+	Src string
+	Alt string
+}
+
+func (o *Options) urlPolicy() *URLPolicy {
+	if o.URLPolicy != nil {
+		return o.URLPolicy
+	}
+	return DefaultURLPolicy
+}
+
+func (p *URLPolicy) sanitize(raw string) (string, bool) {
+	// Browsers read backslashes as slashes, so "/\\evil.com" is protocol-relative.
+	raw = strings.ReplaceAll(strings.TrimSpace(raw), "\\", "/")
+	if raw == "" {
+		return "", false
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	if parsed.Scheme != "" && !p.allowsScheme(parsed.Scheme) {
+		return "", false
+	}
+	if parsed.Scheme == "" && parsed.Opaque != "" {
+		return "", false
+	}
+	// "https:evil.com" has no host for url.Parse, but browsers take evil.com as one.
+	if parsed.Opaque != "" && specialSchemes[strings.ToLower(parsed.Scheme)] {
+		return "", false
+	}
+	if parsed.Host != "" && !p.allowsHost(parsed.Hostname()) {
+		return "", false
+	}
+	if p.Rewrite != nil {
+		rewritten := p.Rewrite(parsed)
+		return rewritten, rewritten != ""
+	}
+	return raw, true
+}
+
+// specialSchemes always carry a host in the URL standard.
+var specialSchemes = map[string]bool{"http": true, "https": true, "ws": true, "wss": true, "ftp": true, "file": true}
+
+func (p *URLPolicy) allowsScheme(scheme string) bool {
+	for _, allowed := range p.AllowedSchemes {
+		if strings.EqualFold(allowed, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *URLPolicy) allowsHost(host string) bool {
+	if len(p.AllowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range p.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if strings.HasPrefix(allowed, ".") {
+			if host == allowed[1:] || strings.HasSuffix(host, allowed) {
+				return true
+			}
+		} else if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func linkValue(value any) (LinkValue, bool) {
+	switch v := value.(type) {
+	case LinkValue:
+		return v, true
+	case *LinkValue:
+		if v == nil {
+			return LinkValue{}, false
+		}
+		return *v, true
+	default:
+		return LinkValue{}, false
+	}
+}
+
+func imageValue(value any) (ImageValue, bool) {
+	switch v := value.(type) {
+	case string:
+		return ImageValue{Src: v}, true
+	case ImageValue:
+		return v, true
+	case *ImageValue:
+		if v == nil {
+			return ImageValue{}, false
+		}
+		return *v, true
+	default:
+		return ImageValue{}, false
+	}
+}
@@ -0,0 +1,91 @@
+package extable
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type linkRow struct {
+	Link  LinkValue `json:"link"`
+	Photo string    `json:"photo"`
+}
+
+func TestURLPolicyDefault(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com/a":        true,
+		"/orders/1":                    true,
+		"mailto:a@example.com":         true,
+		"javascript:alert(1)":          false,
+		"JavaScript:alert(1)":          false,
+		"java\tscript:alert(1)":        false,
+		"data:text/html;base64,PHNjcj": false,
+		"":                             false,
+	}
+	for raw, want := range cases {
+		if _, ok := DefaultURLPolicy.sanitize(raw); ok != want {
+			t.Errorf("sanitize(%q) = %v, want %v", raw, ok, want)
+		}
+	}
+}
+
+func TestURLPolicyHostsAndRewrite(t *testing.T) {
+	policy := &URLPolicy{
+		AllowedSchemes: []string{"https"},
+		AllowedHosts:   []string{".example.com"},
+		Rewrite: func(u *url.URL) string {
+			u.RawQuery = "ref=table"
+			return u.String()
+		},
+	}
+	if href, ok := policy.sanitize("https://cdn.example.com/x"); !ok || href != "https://cdn.example.com/x?ref=table" {
+		t.Fatalf("unexpected rewrite: %q %v", href, ok)
+	}
+	if _, ok := policy.sanitize("https://evil.test/x"); ok {
+		t.Fatalf("expected host to be rejected")
+	}
+	if _, ok := policy.sanitize("//evil.test/x"); ok {
+		t.Fatalf("expected protocol-relative host to be rejected")
+	}
+	for _, raw := range []string{`/\evil.test/x`, `\\evil.test/x`, `\/evil.test`, "https:evil.test/x", `https:\\evil.test`} {
+		if href, ok := policy.sanitize(raw); ok {
+			t.Errorf("expected %q to be rejected, got %q", raw, href)
+		}
+	}
+	if href, ok := policy.sanitize(`/docs\a`); !ok || href != "/docs/a?ref=table" {
+		t.Fatalf("expected backslashes in paths to become slashes, got %q %v", href, ok)
+	}
+}
+
+func TestRenderLinkAndImageColumns(t *testing.T) {
+	schema := Schema[linkRow]{Columns: []Column[linkRow]{
+		{Key: "link", Type: ColumnTypeLink},
+		{Key: "photo", Type: ColumnTypeImage},
+	}}
+	result, err := RenderTableHTML(
+		[]linkRow{
+			{Link: LinkValue{Label: "Open", Href: "https://example.com/?a=1&b=2", Target: "_blank"}, Photo: "/img/a.png"},
+			{Link: LinkValue{Label: "Bad", Href: "javascript:alert(1)"}, Photo: "data:image/png;base64,AAAA"},
+		},
+		schema,
+		Options{},
+	)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(result.HTML, `<a class="extable-action-link" href="https://example.com/?a=1&amp;b=2" target="_blank" rel="noopener noreferrer">Open</a>`) {
+		t.Fatalf("expected anchor: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<img class="extable-image" src="/img/a.png" alt="">`) {
+		t.Fatalf("expected image: %s", result.HTML)
+	}
+	if strings.Contains(result.HTML, "javascript:") || strings.Contains(result.HTML, "data:image") {
+		t.Fatalf("expected unsafe URLs to be dropped: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, `<span class="extable-action-link">Bad</span>`) {
+		t.Fatalf("expected rejected link to render as text")
+	}
+	if len(result.Metadata.Warnings) != 2 {
+		t.Fatalf("expected policy warnings: %+v", result.Metadata.Warnings)
+	}
+}
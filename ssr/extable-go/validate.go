@@ -0,0 +1,156 @@
+package extable
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OptionsError lists every problem found by Options.Validate.
+type OptionsError struct {
+	Problems []string
+}
+
+func (e *OptionsError) Error() string {
+	return "ssr: invalid options: " + strings.Join(e.Problems, "; ")
+}
+
+func (e *OptionsError) Unwrap() error {
+	return ErrInvalidOptions
+}
+
+// Validate reports settings that are out of range or contradict each other.
+// Schema.ValidateOptions adds the checks that need the schema; rendering runs
+// those, so either is only needed to check options ahead of time.
+func (o *Options) Validate() error {
+	if problems := o.problems(); len(problems) > 0 {
+		return &OptionsError{Problems: problems}
+	}
+	return nil
+}
+
+// ValidateOptions is Options.Validate plus the combinations that depend on
+// the schema, such as GroupBy with Children.
+func (s Schema[T]) ValidateOptions(o Options) error {
+	problems := o.problems()
+	if o.GroupBy != "" && s.Children != nil {
+		problems = append(problems, "GroupBy cannot be combined with Schema.Children")
+	}
+	if len(problems) > 0 {
+		return &OptionsError{Problems: problems}
+	}
+	return nil
+}
+
+func (o *Options) problems() []string {
+	var problems []string
+	if o.Direction != "" && o.Direction != DirectionLTR && o.Direction != DirectionRTL {
+		problems = append(problems, fmt.Sprintf("unknown direction %q", o.Direction))
+	}
+	if strings.Trim(o.Indent, " \t") != "" {
+		problems = append(problems, "Indent must contain only spaces and tabs")
+	}
+	if o.Offset < 0 {
+		problems = append(problems, "Offset must not be negative")
+	}
+	if len(o.CollapsedGroups) > 0 && o.GroupBy == "" {
+		problems = append(problems, "CollapsedGroups needs GroupBy")
+	}
+	if o.FrozenHeaderRows < 0 {
+		problems = append(problems, "FrozenHeaderRows must not be negative")
+	}
+	if o.Timeout < 0 {
+		problems = append(problems, "Timeout must not be negative")
+	}
+	if o.Limit < 0 {
+		problems = append(problems, "Limit must not be negative")
+	}
+	if o.FormMode != nil && !strings.EqualFold(o.FormMode.method(), "post") && !strings.EqualFold(o.FormMode.method(), "get") {
+		problems = append(problems, fmt.Sprintf("FormMode.Method must be get or post, not %q", o.FormMode.Method))
+	}
+	if o.RowHeight < 0 {
+		problems = append(problems, "RowHeight must not be negative")
+	}
+	seen := make(map[string]bool, len(o.Sort))
+	for _, spec := range o.Sort {
+		if spec.Key == "" {
+			problems = append(problems, "Sort has an entry without a key")
+			continue
+		}
+		if seen[spec.Key] {
+			problems = append(problems, fmt.Sprintf("Sort lists key %q more than once", spec.Key))
+		}
+		seen[spec.Key] = true
+	}
+	if o.Responsive != nil && o.Responsive.Mode != ResponsiveStack {
+		problems = append(problems, fmt.Sprintf("unknown responsive mode %q", o.Responsive.Mode))
+	}
+	if o.Responsive != nil && o.RowHeight > 0 {
+		problems = append(problems, "RowHeight cannot be combined with Responsive, whose stacked rows vary in height")
+	}
+	if o.NewRowPlaceholder && o.GroupBy != "" {
+		problems = append(problems, "NewRowPlaceholder cannot be combined with GroupBy")
+	}
+	if o.Highlight != nil && o.Highlight.Term == "" {
+		problems = append(problems, "Highlight.Term is empty")
+	}
+	if o.CSRF != nil && o.CSRF.Token == "" {
+		problems = append(problems, "CSRF.Token is empty")
+	}
+	for _, name := range o.ChecksumIgnoreAttrs {
+		if name == "" {
+			problems = append(problems, "ChecksumIgnoreAttrs contains an empty name")
+			break
+		}
+	}
+	return problems
+}
+
+// Validate checks the schema as rendering would, and additionally rejects
+// duplicate keys and keys that match no field of T (derived columns excepted).
+// It returns the first problem found.
+func (s Schema[T]) Validate() error {
+	getter, err := newFieldGetter[T]()
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(s.Columns))
+	for _, col := range s.Columns {
+		if col.Key == "" {
+			return ErrMissingColumnKey
+		}
+		if seen[col.Key] {
+			return &SchemaError{ColKey: col.Key, Message: "is defined more than once"}
+		}
+		seen[col.Key] = true
+		if !isKnownColumnType(col.Type) {
+			return &SchemaError{ColKey: col.Key, Message: fmt.Sprintf("has unknown type %q", col.Type)}
+		}
+		if !col.derived() && !getter.hasKey(col.Key) {
+			return &SchemaError{ColKey: col.Key, Message: "matches no field of the row type"}
+		}
+		for _, action := range col.Actions {
+			if action.ID == "" {
+				return &SchemaError{ColKey: col.Key, Message: "has an action without ID"}
+			}
+		}
+	}
+	if err := checkCustomFormats(s.Columns); err != nil {
+		return err
+	}
+	if err := checkDateStyles(s.Columns); err != nil {
+		return err
+	}
+	if err := checkPeriods(s.Columns); err != nil {
+		return err
+	}
+	if err := checkGeoSpecs(s.Columns); err != nil {
+		return err
+	}
+	if err := checkTableSpecs(s.Columns); err != nil {
+		return err
+	}
+	if err := checkTextTemplates(s.Columns); err != nil {
+		return err
+	}
+	return checkExpressions(s.Columns)
+}
@@ -0,0 +1,24 @@
+package extable
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Version is the extable release this renderer targets. It is emitted as
+// data-extable-ssr-version on the root element.
+const Version = "0.3.9"
+
+// SchemaHash fingerprints the client-visible part of a schema (the same data
+// as the state script), so a hydrating client can detect that the server
+// rendered with a different schema. Columns hidden by Schema.ColumnVisible
+// are left out.
+func SchemaHash[T any](schema Schema[T]) string {
+	payload, err := json.Marshal(buildState(schema.visibleColumns()))
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,36 @@
+package extable
+
+func (s Schema[T]) columnVisible(col Column[T]) bool {
+	return s.ColumnVisible == nil || s.ColumnVisible(col)
+}
+
+// visibleColumns returns the columns Schema.ColumnVisible accepts.
+func (s Schema[T]) visibleColumns() []Column[T] {
+	if s.ColumnVisible == nil {
+		return s.Columns
+	}
+	columns := make([]Column[T], 0, len(s.Columns))
+	for _, col := range s.Columns {
+		if s.ColumnVisible(col) {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
+// visible returns c without the columns Schema.ColumnVisible rejects.
+func (c *compiledSchema[T]) visible() *compiledSchema[T] {
+	if c.schema.ColumnVisible == nil {
+		return c
+	}
+	visible := *c
+	visible.schema.Columns = make([]Column[T], 0, len(c.schema.Columns))
+	visible.hrefs = make([]*hrefTemplate, 0, len(c.hrefs))
+	for i, col := range c.schema.Columns {
+		if c.schema.ColumnVisible(col) {
+			visible.schema.Columns = append(visible.schema.Columns, col)
+			visible.hrefs = append(visible.hrefs, c.hrefs[i])
+		}
+	}
+	return &visible
+}
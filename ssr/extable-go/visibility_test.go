@@ -0,0 +1,144 @@
+package extable
+
+import (
+	"context"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type employee struct {
+	Name   string  `json:"name"`
+	Salary float64 `json:"salary"`
+}
+
+func TestColumnVisible(t *testing.T) {
+	schema := Schema[employee]{
+		Columns: []Column[employee]{
+			{Key: "name", Type: ColumnTypeString},
+			{Key: "salary", Type: ColumnTypeNumber},
+		},
+		ColumnVisible: func(col Column[employee]) bool { return col.Key != "salary" },
+	}
+	rows := []employee{{Name: "Ada", Salary: 98765}}
+	result, err := RenderTableHTML(rows, schema, Options{EmitState: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Contains(result.HTML, "salary") || strings.Contains(result.HTML, "98765") {
+		t.Fatalf("hidden column leaked: %s", result.HTML)
+	}
+	if result.Metadata.ColumnCount != 1 || !strings.Contains(result.HTML, `"key":"name"`) {
+		t.Fatalf("visible column missing: %+v %s", result.Metadata, result.HTML)
+	}
+
+	_, err = RenderTableHTML(rows, schema, Options{Sort: []SortSpec{{Key: "salary"}}})
+	if err == nil || !strings.Contains(err.Error(), "not a schema column") {
+		t.Fatalf("sorting by a hidden column should fail, got %v", err)
+	}
+
+	fragments, err := RenderRowFragments(rows, schema, Options{})
+	if err != nil || strings.Contains(fragments[0], "98765") {
+		t.Fatalf("hidden column leaked in fragment: %v %v", err, fragments)
+	}
+
+	root, err := RenderTableHTML(rows, schema, Options{WrapWithRoot: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	visibleOnly := Schema[employee]{Columns: schema.Columns[:1]}
+	if !strings.Contains(root.HTML, `data-schema-hash="`+SchemaHash(visibleOnly)+`"`) {
+		t.Fatalf("expected the schema hash to cover only visible columns: %s", root.HTML)
+	}
+	renderer, err := NewRenderer(schema, Options{WrapWithRoot: true})
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+	if compiled, _ := renderer.Render(rows); compiled.HTML != root.HTML {
+		t.Fatalf("renderer hash differs from RenderTableHTML:\n%s\n%s", compiled.HTML, root.HTML)
+	}
+
+	edits, _ := DecodeFormEdits(url.Values{"cell[0][name]": {"Grace"}, "cell[0][salary]": {"1"}}, schema)
+	if len(edits) != 1 || edits[0].ColKey != "name" {
+		t.Fatalf("expected edits to hidden columns to be dropped, got %+v", edits)
+	}
+}
+
+func TestDecodeMultipartEditsHiddenColumns(t *testing.T) {
+	schema := fileSchema
+	schema.Columns = append([]Column[documentRow]{}, fileSchema.Columns...)
+	schema.Columns = append(schema.Columns,
+		Column[documentRow]{Key: "scan", Type: ColumnTypeFile, File: &FileSpec{}},
+		Column[documentRow]{Key: "generated", Type: ColumnTypeFile, File: &FileSpec{}, Formula: func(documentRow) any { return nil }},
+	)
+	schema.ColumnVisible = func(col Column[documentRow]) bool { return col.Key != "scan" }
+	upload := []*multipart.FileHeader{{Filename: "a.pdf", Size: 10}}
+	form := &multipart.Form{File: map[string][]*multipart.FileHeader{
+		"cell[d1][attachment]": upload,
+		"cell[d1][scan]":       upload,
+		"cell[d1][generated]":  upload,
+	}}
+	edits, _ := DecodeMultipartEdits(form, schema)
+	if len(edits) != 1 || edits[0].ColKey != "attachment" {
+		t.Fatalf("expected uploads to hidden and derived columns to be dropped, got %+v", edits)
+	}
+}
+
+func TestColumnVisibleExportsAndPatches(t *testing.T) {
+	schema := exportStreamSchema
+	schema.ColumnVisible = func(col Column[exportRow]) bool { return col.Key != "name" }
+	rows := []exportRow{{ID: 1, Name: "secret", Active: true}}
+	var csv strings.Builder
+	if err := ExportCSV(context.Background(), &csv, rows, schema, StreamOptions{}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if csv.String() != "ID,active\n1,true\n" {
+		t.Fatalf("hidden column leaked in csv: %q", csv.String())
+	}
+	var sql strings.Builder
+	if err := ExportSQL(&sql, rows, schema, SQLOptions{Table: "users"}); err != nil || strings.Contains(sql.String(), "secret") {
+		t.Fatalf("hidden column leaked in sql: %v %s", err, sql.String())
+	}
+
+	schema.RowID = func(row exportRow) string { return strconv.Itoa(row.ID) }
+	ops, err := ComputePatch(rows, []exportRow{{ID: 1, Name: "changed", Active: true}, {ID: 2, Name: "secret"}}, schema)
+	if err != nil {
+		t.Fatalf("compute failed: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Op != "add" {
+		t.Fatalf("expected only the added row, got %+v", ops)
+	}
+	if _, leaked := ops[0].Value.(map[string]any)["name"]; leaked {
+		t.Fatalf("hidden column leaked in patch: %+v", ops[0])
+	}
+}
+
+func TestRendererViewColumnVisible(t *testing.T) {
+	schema := Schema[employee]{Columns: []Column[employee]{
+		{Key: "name", Type: ColumnTypeString},
+		{Key: "salary", Type: ColumnTypeNumber},
+	}}
+	renderer, err := NewRenderer(schema, Options{WrapWithRoot: true, EmitState: true})
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+	rows := []employee{{Name: "Ada", Salary: 98765}}
+	hideSalary := func(col Column[employee]) bool { return col.Key != "salary" }
+	viewed, err := renderer.RenderView(context.Background(), rows, View[employee]{ColumnVisible: hideSalary})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	schema.ColumnVisible = hideSalary
+	expected, err := RenderTableHTML(rows, schema, Options{WrapWithRoot: true, EmitState: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if viewed.HTML != expected.HTML {
+		t.Fatalf("view render differs from a schema with ColumnVisible:\n%s\n%s", viewed.HTML, expected.HTML)
+	}
+	if full, _ := renderer.Render(rows); !strings.Contains(full.HTML, "98765") {
+		t.Fatalf("expected the shared renderer to keep every column: %s", full.HTML)
+	}
+}
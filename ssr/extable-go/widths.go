@@ -0,0 +1,25 @@
+package extable
+
+import "unicode/utf8"
+
+func headerWidths[T any](columns []Column[T]) []int {
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = utf8.RuneCountInString(columnHeader(col))
+	}
+	return widths
+}
+
+func (r *tableRenderer[T]) observeWidth(colIndex int, text string) {
+	if n := utf8.RuneCountInString(text); n > r.widths[colIndex] {
+		r.widths[colIndex] = n
+	}
+}
+
+// observeRowWidths measures a row whose markup came from the cache.
+func (r *tableRenderer[T]) observeRowWidths(row T) {
+	for i, col := range r.columns {
+		value, _ := columnValue(r.getter, row, col)
+		r.observeWidth(i, formatValue(value, col, r.opts))
+	}
+}